@@ -0,0 +1,180 @@
+package ast
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// IncludeAttrLocals is the attribute of an exposed include that holds
+	// the locals of the included file.
+	IncludeAttrLocals = "locals"
+
+	// IncludeAttrInputs is the attribute of an exposed include that holds
+	// the inputs of the included file.
+	IncludeAttrInputs = "inputs"
+
+	// includeReferenceTraversalLen is the number of steps of an
+	// `include.<label>.<attr>.<name>` reference.
+	includeReferenceTraversalLen = 4
+)
+
+// IncludeReference is an `include.<label>.locals.<name>` or
+// `include.<label>.inputs.<name>` reference to a local or input of an
+// included file.
+type IncludeReference struct {
+	// Label is the label of the include block.
+	Label string
+	// Attr is either IncludeAttrLocals or IncludeAttrInputs.
+	Attr string
+	// Name is the name of the local or input.
+	Name string
+	// NameRange is the range of the name alone.
+	NameRange hcl.Range
+}
+
+// GetIncludeReference returns the include reference expr is, if any. Steps
+// past the name, such as `include.root.locals.tags.env`, are ignored.
+func GetIncludeReference(expr *hclsyntax.ScopeTraversalExpr) (IncludeReference, bool) {
+	if len(expr.Traversal) < includeReferenceTraversalLen {
+		return IncludeReference{}, false
+	}
+
+	rootStep, ok := expr.Traversal[0].(hcl.TraverseRoot)
+	if !ok || rootStep.Name != "include" {
+		return IncludeReference{}, false
+	}
+
+	labelStep, ok := expr.Traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return IncludeReference{}, false
+	}
+
+	attrStep, ok := expr.Traversal[2].(hcl.TraverseAttr)
+	if !ok || attrStep.Name != IncludeAttrLocals && attrStep.Name != IncludeAttrInputs {
+		return IncludeReference{}, false
+	}
+
+	nameStep, ok := expr.Traversal[3].(hcl.TraverseAttr)
+	if !ok {
+		return IncludeReference{}, false
+	}
+
+	return IncludeReference{
+		Label:     labelStep.Name,
+		Attr:      attrStep.Name,
+		Name:      nameStep.Name,
+		NameRange: TraverseAttrIdentRange(nameStep),
+	}, true
+}
+
+// IncludeReferences returns the include references of body, in the order
+// they appear.
+func IncludeReferences(body *hclsyntax.Body) []IncludeReference {
+	if body == nil {
+		return nil
+	}
+
+	var refs []IncludeReference
+
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+			if ref, ok := GetIncludeReference(expr); ok {
+				refs = append(refs, ref)
+			}
+		}
+
+		return nil
+	})
+
+	return refs
+}
+
+// WalkIncludeReferences walks body and invokes visitor for each
+// `include.<label>.<attr>.<name>` reference. The range passed to visitor is
+// the range of the name alone.
+func WalkIncludeReferences(body *hclsyntax.Body, label, attr, name string, visitor ReferenceVisitor) {
+	if body == nil {
+		return
+	}
+
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+
+		ref, ok := GetIncludeReference(expr)
+		if !ok || ref.Label != label || ref.Attr != attr || ref.Name != name {
+			return nil
+		}
+
+		visitor(expr, ref.NameRange)
+
+		return nil
+	})
+}
+
+// FindIncludedAttribute returns the declaration of a local or input of the
+// file, as the range of its name and the range of the whole declaration.
+// attr is either IncludeAttrLocals or IncludeAttrInputs.
+func (d *IndexedAST) FindIncludedAttribute(attr, name string) (nameRange, declRange hcl.Range, ok bool) {
+	switch attr {
+	case IncludeAttrLocals:
+		node, ok := d.Locals[name]
+		if !ok {
+			return hcl.Range{}, hcl.Range{}, false
+		}
+
+		local, ok := node.Node.(*hclsyntax.Attribute)
+		if !ok {
+			return hcl.Range{}, hcl.Range{}, false
+		}
+
+		return local.NameRange, local.SrcRange, true
+
+	case IncludeAttrInputs:
+		if d.HCLFile == nil {
+			return hcl.Range{}, hcl.Range{}, false
+		}
+
+		body, ok := d.HCLFile.Body.(*hclsyntax.Body)
+		if !ok {
+			return hcl.Range{}, hcl.Range{}, false
+		}
+
+		inputs, ok := body.Attributes[IncludeAttrInputs]
+		if !ok {
+			return hcl.Range{}, hcl.Range{}, false
+		}
+
+		object, ok := inputs.Expr.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			return hcl.Range{}, hcl.Range{}, false
+		}
+
+		for _, item := range object.Items {
+			if key, ok := ObjectKeyName(item.KeyExpr); ok && key == name {
+				return item.KeyExpr.Range(), hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()), true
+			}
+		}
+	}
+
+	return hcl.Range{}, hcl.Range{}, false
+}
+
+// ObjectKeyName returns the name of an object key that is a bare identifier
+// or a string literal.
+func ObjectKeyName(expr hclsyntax.Expression) (string, bool) {
+	if keyword := hcl.ExprAsKeyword(expr); keyword != "" {
+		return keyword, true
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", false
+	}
+
+	return value.AsString(), true
+}
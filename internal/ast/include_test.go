@@ -0,0 +1,122 @@
+package ast_test
+
+import (
+	"terragrunt-ls/internal/ast"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkIncludeReferences(t *testing.T) {
+	t.Parallel()
+
+	contents := `inputs = {
+  a = include.root.locals.region
+  b = include.root.inputs.region
+  c = include.other.locals.region
+  d = include.root.locals.region.name
+  e = include.root.locals
+}
+`
+
+	iast, err := ast.ParseHCLFile("test.hcl", []byte(contents))
+	require.NoError(t, err)
+
+	body, ok := iast.HCLFile.Body.(*hclsyntax.Body)
+	require.True(t, ok)
+
+	var got []hcl.Pos
+
+	ast.WalkIncludeReferences(body, "root", ast.IncludeAttrLocals, "region", func(_ *hclsyntax.ScopeTraversalExpr, r hcl.Range) {
+		got = append(got, r.Start)
+	})
+
+	require.Len(t, got, 2)
+	assert.ElementsMatch(t, []int{2, 5}, []int{got[0].Line, got[1].Line})
+
+	for _, pos := range got {
+		assert.Equal(t, 27, pos.Column)
+	}
+}
+
+func TestIndexedAST_FindIncludedAttribute(t *testing.T) {
+	t.Parallel()
+
+	contents := `locals {
+  region = "us-east-1"
+}
+
+inputs = {
+  env     = "dev"
+  "quoted" = true
+}
+`
+
+	iast, err := ast.ParseHCLFile("root.hcl", []byte(contents))
+	require.NoError(t, err)
+
+	tc := []struct {
+		name         string
+		attr         string
+		key          string
+		expectedName hcl.Pos
+		expectedDecl hcl.Pos
+		found        bool
+	}{
+		{
+			name:         "local",
+			attr:         ast.IncludeAttrLocals,
+			key:          "region",
+			expectedName: hcl.Pos{Line: 2, Column: 3},
+			expectedDecl: hcl.Pos{Line: 2, Column: 23},
+			found:        true,
+		},
+		{
+			name:         "input",
+			attr:         ast.IncludeAttrInputs,
+			key:          "env",
+			expectedName: hcl.Pos{Line: 6, Column: 3},
+			expectedDecl: hcl.Pos{Line: 6, Column: 18},
+			found:        true,
+		},
+		{
+			name:         "quoted input",
+			attr:         ast.IncludeAttrInputs,
+			key:          "quoted",
+			expectedName: hcl.Pos{Line: 7, Column: 3},
+			expectedDecl: hcl.Pos{Line: 7, Column: 18},
+			found:        true,
+		},
+		{
+			name: "missing local",
+			attr: ast.IncludeAttrLocals,
+			key:  "env",
+		},
+		{
+			name: "missing input",
+			attr: ast.IncludeAttrInputs,
+			key:  "region",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameRange, declRange, ok := iast.FindIncludedAttribute(tt.attr, tt.key)
+			require.Equal(t, tt.found, ok)
+
+			if !tt.found {
+				return
+			}
+
+			assert.Equal(t, tt.expectedName.Line, nameRange.Start.Line)
+			assert.Equal(t, tt.expectedName.Column, nameRange.Start.Column)
+			assert.Equal(t, tt.expectedDecl.Line, declRange.End.Line)
+			assert.Equal(t, tt.expectedDecl.Column, declRange.End.Column)
+		})
+	}
+}
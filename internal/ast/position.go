@@ -38,3 +38,12 @@ func ToHCLPos(s protocol.Position) hcl.Pos {
 		Byte:   0,
 	}
 }
+
+// RangeContains reports whether pos is in r, comparing lines and columns, as
+// positions converted from the LSP have no byte offset.
+func RangeContains(r hcl.Range, pos hcl.Pos) bool {
+	afterStart := pos.Line > r.Start.Line || pos.Line == r.Start.Line && pos.Column >= r.Start.Column
+	beforeEnd := pos.Line < r.End.Line || pos.Line == r.End.Line && pos.Column < r.End.Column
+
+	return afterStart && beforeEnd
+}
@@ -4,12 +4,17 @@ package config
 import (
 	"log/slog"
 	"os"
+	"path/filepath"
 )
 
 // Config holds the configuration for terragrunt-ls
 type Config struct {
 	// LogFile is the path to the log file, empty string means stderr
 	LogFile string
+	// Listen is the address to accept connections on, empty string means stdio
+	Listen string
+	// CacheDir is the directory the index cache is stored in, empty string means no cache
+	CacheDir string
 	// LogLevel is the log level to use
 	LogLevel slog.Level
 }
@@ -19,15 +24,28 @@ const (
 	EnvLogFile = "TG_LS_LOG"
 	// EnvLogLevel is the environment variable that specifies the log level.
 	EnvLogLevel = "TG_LS_LOG_LEVEL"
+	// EnvListen is the environment variable that specifies the address to listen on.
+	EnvListen = "TG_LS_LISTEN"
+	// EnvCacheDir is the environment variable that specifies the directory of the
+	// index cache. Setting it to an empty string disables the cache.
+	EnvCacheDir = "TG_LS_CACHE_DIR"
 )
 
 // Load reads configuration from environment variables and returns a populated Config
 func Load() *Config {
 	cfg := &Config{
 		LogFile:  os.Getenv(EnvLogFile),
+		Listen:   os.Getenv(EnvListen),
 		LogLevel: slog.LevelInfo, // default level
 	}
 
+	// Default to a directory of the user cache directory, when there is one
+	if dir, ok := os.LookupEnv(EnvCacheDir); ok {
+		cfg.CacheDir = dir
+	} else if dir, err := os.UserCacheDir(); err == nil {
+		cfg.CacheDir = filepath.Join(dir, "terragrunt-ls")
+	}
+
 	// Parse log level from environment variable
 	if envLevel := os.Getenv(EnvLogLevel); envLevel != "" {
 		levelVar := slog.LevelVar{}
@@ -0,0 +1,11 @@
+package lsp
+
+type CancelRequestNotification struct {
+	Notification
+	Params CancelParams `json:"params"`
+}
+
+// CancelParams identifies the request to cancel.
+type CancelParams struct {
+	ID ID `json:"id"`
+}
@@ -0,0 +1,38 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ClientRequest is a request sent by the server to the client.
+type ClientRequest struct {
+	Params any `json:"params,omitempty"`
+	Request
+}
+
+// NewClientRequest builds a request to the client with the given ID.
+func NewClientRequest(id ID, method string, params any) ClientRequest {
+	return ClientRequest{
+		Request: Request{
+			RPC:    RPCVersion,
+			ID:     id,
+			Method: method,
+		},
+		Params: params,
+	}
+}
+
+// ClientResponse is the response of the client to a ClientRequest.
+//
+// Exactly one of Result and Error is set.
+type ClientResponse struct {
+	Error  *ResponseError  `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Response
+}
+
+// Error makes a ResponseError received from the client usable as an error.
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
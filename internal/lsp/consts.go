@@ -1,8 +1,11 @@
 package lsp
 
 const (
-	name    = "terragrunt-ls"
-	version = "0.1.0"
+	name = "terragrunt-ls"
+
+	// Version is the version of terragrunt-ls, sent to the client in
+	// `initialize`.
+	Version = "0.1.0"
 
 	RPCVersion = "2.0"
 )
@@ -0,0 +1,34 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+// MethodDependents is the custom request for the units that depend on a unit.
+const MethodDependents = "terragrunt/dependents"
+
+type DependentsRequest struct {
+	Params DependentsParams `json:"params"`
+	Request
+}
+
+// DependentsParams identifies the unit to find the dependents of.
+type DependentsParams struct {
+	TextDocument protocol.TextDocumentIdentifier `json:"textDocument"`
+}
+
+type DependentsResponse struct {
+	Response
+	Result []Dependent `json:"result"`
+}
+
+// Dependent is a reference from a unit to the unit it depends on.
+type Dependent struct {
+	// URI is the configuration of the dependent unit.
+	URI protocol.DocumentURI `json:"uri"`
+
+	// Name is the label of the `dependency` block, and is omitted for the
+	// paths of a `dependencies` block.
+	Name string `json:"name,omitempty"`
+
+	// Range is the range of the path that resolves to the unit.
+	Range protocol.Range `json:"range"`
+}
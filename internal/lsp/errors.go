@@ -0,0 +1,59 @@
+package lsp
+
+// ErrorCode is a JSON-RPC error code, as used in the `error` member of a response.
+type ErrorCode int
+
+const (
+	// ErrorCodeParseError signals that the message was not valid JSON.
+	ErrorCodeParseError ErrorCode = -32700
+
+	// ErrorCodeInvalidRequest signals that the message is not a valid request,
+	// or is not valid in the current server state.
+	ErrorCodeInvalidRequest ErrorCode = -32600
+
+	// ErrorCodeMethodNotFound signals that the server does not support the requested method.
+	ErrorCodeMethodNotFound ErrorCode = -32601
+
+	// ErrorCodeInvalidParams signals that the params of the request could not be parsed.
+	ErrorCodeInvalidParams ErrorCode = -32602
+
+	// ErrorCodeInternalError signals that the server failed while serving the request.
+	ErrorCodeInternalError ErrorCode = -32603
+
+	// ErrorCodeServerNotInitialized signals that a request was sent before `initialize`.
+	ErrorCodeServerNotInitialized ErrorCode = -32002
+
+	// ErrorCodeRequestCancelled signals that the client cancelled the request.
+	ErrorCodeRequestCancelled ErrorCode = -32800
+)
+
+// ResponseError is the `error` member of a failed response.
+type ResponseError struct {
+	Message string    `json:"message"`
+	Code    ErrorCode `json:"code"`
+}
+
+// ErrorResponse is sent in reply to a request that failed.
+//
+// It carries an `error` member in place of `result`, as JSON-RPC forbids a
+// response from having both.
+type ErrorResponse struct {
+	Error ResponseError `json:"error"`
+	Response
+}
+
+// NewErrorResponse builds an error response to the request with the given ID.
+//
+// Use NullID when the ID of the request could not be determined.
+func NewErrorResponse(id ID, code ErrorCode, message string) ErrorResponse {
+	return ErrorResponse{
+		Response: Response{
+			RPC: RPCVersion,
+			ID:  id,
+		},
+		Error: ResponseError{
+			Code:    code,
+			Message: message,
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// idKind identifies which member of the ID union is set.
+type idKind int
+
+const (
+	// idKindAbsent is the kind of a message without an `id` member, i.e. a notification.
+	idKindAbsent idKind = iota
+	// idKindNull is the kind of an explicit `"id": null`.
+	idKindNull
+	// idKindNumber is the kind of a numeric ID.
+	idKindNumber
+	// idKindString is the kind of a string ID.
+	idKindString
+)
+
+// ID is a JSON-RPC request ID, which may be a number, a string, or null.
+//
+// The zero value is an absent ID, as found on notifications. IDs are
+// comparable, so they can be used as map keys.
+type ID struct {
+	str  string
+	num  int64
+	kind idKind
+}
+
+// NewNumberID returns a numeric ID.
+func NewNumberID(n int64) ID {
+	return ID{num: n, kind: idKindNumber}
+}
+
+// NewStringID returns a string ID.
+func NewStringID(s string) ID {
+	return ID{str: s, kind: idKindString}
+}
+
+// NullID returns the null ID, used when replying to a request whose ID could not be read.
+func NullID() ID {
+	return ID{kind: idKindNull}
+}
+
+// IsSet reports whether the message carried an `id` member, i.e. whether it
+// is a request rather than a notification.
+func (id ID) IsSet() bool {
+	return id.kind != idKindAbsent
+}
+
+// String returns the ID as it appears in JSON, for logging.
+func (id ID) String() string {
+	switch id.kind {
+	case idKindNumber:
+		return strconv.FormatInt(id.num, 10)
+	case idKindString:
+		return strconv.Quote(id.str)
+	case idKindAbsent, idKindNull:
+		return "null"
+	}
+
+	return "null"
+}
+
+// MarshalJSON encodes the ID as a JSON number, string, or null.
+func (id ID) MarshalJSON() ([]byte, error) {
+	switch id.kind {
+	case idKindNumber:
+		return strconv.AppendInt(nil, id.num, 10), nil
+	case idKindString:
+		return json.Marshal(id.str)
+	case idKindAbsent, idKindNull:
+		return []byte("null"), nil
+	}
+
+	return []byte("null"), nil
+}
+
+// ErrInvalidID is returned when an ID is neither an integer, a string, nor null.
+var ErrInvalidID = errors.New("JSON-RPC id must be an integer, a string, or null")
+
+// UnmarshalJSON decodes a JSON number, string, or null into the ID.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*id = NullID()
+
+		return nil
+
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		*id = NewStringID(s)
+
+		return nil
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return ErrInvalidID
+	}
+
+	*id = NewNumberID(n)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package lsp_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/lsp"
+)
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		message  string
+		expected lsp.ID
+		isSet    bool
+	}{
+		{
+			name:     "number",
+			message:  `{"id": 7}`,
+			expected: lsp.NewNumberID(7),
+			isSet:    true,
+		},
+		{
+			name:     "string",
+			message:  `{"id": "abc-123"}`,
+			expected: lsp.NewStringID("abc-123"),
+			isSet:    true,
+		},
+		{
+			name:     "numeric string stays a string",
+			message:  `{"id": "7"}`,
+			expected: lsp.NewStringID("7"),
+			isSet:    true,
+		},
+		{
+			name:     "null",
+			message:  `{"id": null}`,
+			expected: lsp.NullID(),
+			isSet:    true,
+		},
+		{
+			name:     "absent",
+			message:  `{}`,
+			expected: lsp.ID{},
+			isSet:    false,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var request lsp.Request
+			require.NoError(t, json.Unmarshal([]byte(tt.message), &request))
+
+			assert.Equal(t, tt.expected, request.ID)
+			assert.Equal(t, tt.isSet, request.ID.IsSet())
+		})
+	}
+}
+
+func TestID_UnmarshalJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, message := range []string{`{"id": 1.5}`, `{"id": true}`, `{"id": {}}`} {
+		var request lsp.Request
+		assert.Error(t, json.Unmarshal([]byte(message), &request), message)
+	}
+}
+
+func TestID_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		expected string
+		id       lsp.ID
+	}{
+		{
+			name:     "number",
+			id:       lsp.NewNumberID(7),
+			expected: `{"id":7,"jsonrpc":"2.0"}`,
+		},
+		{
+			name:     "string",
+			id:       lsp.NewStringID("abc-123"),
+			expected: `{"id":"abc-123","jsonrpc":"2.0"}`,
+		},
+		{
+			name:     "null",
+			id:       lsp.NullID(),
+			expected: `{"id":null,"jsonrpc":"2.0"}`,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := json.Marshal(lsp.Response{RPC: lsp.RPCVersion, ID: tt.id})
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+}
@@ -17,15 +17,19 @@ type ServerInfo struct {
 	Version string `json:"version"`
 }
 
-func NewInitializeResponse(id int) InitializeResponse {
+func NewInitializeResponse(id ID) InitializeResponse {
 	return InitializeResponse{
 		Response: Response{
 			RPC: RPCVersion,
-			ID:  &id,
+			ID:  id,
 		},
 		Result: protocol.InitializeResult{
 			Capabilities: protocol.ServerCapabilities{
-				TextDocumentSync:           1,
+				TextDocumentSync: protocol.TextDocumentSyncOptions{
+					OpenClose: true,
+					Change:    protocol.TextDocumentSyncKindIncremental,
+					Save:      &protocol.SaveOptions{},
+				},
 				HoverProvider:              true,
 				DefinitionProvider:         true,
 				ReferencesProvider:         true,
@@ -34,10 +38,21 @@ func NewInitializeResponse(id int) InitializeResponse {
 				RenameProvider: &protocol.RenameOptions{
 					PrepareProvider: true,
 				},
+				DocumentSymbolProvider:  true,
+				WorkspaceSymbolProvider: true,
+				ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
+					Commands: Commands,
+				},
+				Workspace: &protocol.ServerCapabilitiesWorkspace{
+					WorkspaceFolders: &protocol.ServerCapabilitiesWorkspaceFolders{
+						Supported:           true,
+						ChangeNotifications: true,
+					},
+				},
 			},
 			ServerInfo: &protocol.ServerInfo{
 				Name:    name,
-				Version: version,
+				Version: Version,
 			},
 		},
 	}
@@ -1,13 +1,13 @@
 package lsp
 
 type Request struct {
+	ID     ID     `json:"id"`
 	RPC    string `json:"jsonrpc"`
 	Method string `json:"method"`
-	ID     int    `json:"id"`
 }
 
 type Response struct {
-	ID  *int   `json:"id,omitempty"`
+	ID  ID     `json:"id"`
 	RPC string `json:"jsonrpc"`
 }
 
@@ -0,0 +1,11 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+// ProgressNotification reports progress on a token created by the server.
+//
+// Params is a pointer, as protocol.ProgressToken only marshals through one.
+type ProgressNotification struct {
+	Notification
+	Params *protocol.ProgressParams `json:"params"`
+}
@@ -0,0 +1,20 @@
+package lsp
+
+type ShutdownRequest struct {
+	Request
+}
+
+type ShutdownResponse struct {
+	// Result is always null, but must still be present in the response.
+	Result *struct{} `json:"result"`
+	Response
+}
+
+func NewShutdownResponse(id ID) ShutdownResponse {
+	return ShutdownResponse{
+		Response: Response{
+			RPC: RPCVersion,
+			ID:  id,
+		},
+	}
+}
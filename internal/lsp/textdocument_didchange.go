@@ -4,5 +4,21 @@ import "go.lsp.dev/protocol"
 
 type DidChangeTextDocumentNotification struct {
 	Notification
-	Params protocol.DidChangeTextDocumentParams `json:"params"`
+	Params DidChangeTextDocumentParams `json:"params"`
+}
+
+// DidChangeTextDocumentParams mirrors protocol.DidChangeTextDocumentParams,
+// using TextDocumentContentChangeEvent so that ranged and full changes can be told apart.
+type DidChangeTextDocumentParams struct {
+	ContentChanges []TextDocumentContentChangeEvent         `json:"contentChanges"`
+	TextDocument   protocol.VersionedTextDocumentIdentifier `json:"textDocument"`
+}
+
+// TextDocumentContentChangeEvent is a single change to a document.
+//
+// Unlike protocol.TextDocumentContentChangeEvent, the range is optional:
+// a nil Range means Text is the full content of the document.
+type TextDocumentContentChangeEvent struct {
+	Range *protocol.Range `json:"range,omitempty"`
+	Text  string          `json:"text"`
 }
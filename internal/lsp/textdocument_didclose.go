@@ -0,0 +1,8 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+type DidCloseTextDocumentNotification struct {
+	Notification
+	Params protocol.DidCloseTextDocumentParams `json:"params"`
+}
@@ -0,0 +1,8 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+type DidSaveTextDocumentNotification struct {
+	Notification
+	Params protocol.DidSaveTextDocumentParams `json:"params"`
+}
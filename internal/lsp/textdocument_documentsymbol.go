@@ -0,0 +1,13 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+type DocumentSymbolRequest struct {
+	Params protocol.DocumentSymbolParams `json:"params"`
+	Request
+}
+
+type DocumentSymbolResponse struct {
+	Response
+	Result []protocol.DocumentSymbol `json:"result"`
+}
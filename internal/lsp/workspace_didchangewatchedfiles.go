@@ -0,0 +1,8 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+type DidChangeWatchedFilesNotification struct {
+	Notification
+	Params protocol.DidChangeWatchedFilesParams `json:"params"`
+}
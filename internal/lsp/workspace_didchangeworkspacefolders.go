@@ -0,0 +1,8 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+type DidChangeWorkspaceFoldersNotification struct {
+	Notification
+	Params protocol.DidChangeWorkspaceFoldersParams `json:"params"`
+}
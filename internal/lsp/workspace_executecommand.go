@@ -0,0 +1,41 @@
+package lsp
+
+import (
+	"encoding/json"
+
+	"go.lsp.dev/protocol"
+)
+
+// CommandDependencyGraph is the command that returns the dependency graph of
+// the units of the workspace, or of a directory of it.
+const CommandDependencyGraph = "terragrunt.dependencyGraph"
+
+// Commands are the commands the server executes through `workspace/executeCommand`.
+var Commands = []string{CommandDependencyGraph}
+
+type ExecuteCommandRequest struct {
+	Params ExecuteCommandParams `json:"params"`
+	Request
+}
+
+// ExecuteCommandParams are the params of `workspace/executeCommand`, with the
+// arguments left raw, as their shape depends on the command.
+type ExecuteCommandParams struct {
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+type ExecuteCommandResponse struct {
+	Result any `json:"result"`
+	Response
+}
+
+// DependencyGraphArguments is the argument of CommandDependencyGraph.
+type DependencyGraphArguments struct {
+	// URI is the directory to graph the units under. It defaults to the
+	// workspace folder, when there is a single one.
+	URI protocol.DocumentURI `json:"uri,omitempty"`
+
+	// Format is one of `json`, the default, `dot` or `mermaid`.
+	Format string `json:"format,omitempty"`
+}
@@ -0,0 +1,13 @@
+package lsp
+
+import "go.lsp.dev/protocol"
+
+type WorkspaceSymbolRequest struct {
+	Params protocol.WorkspaceSymbolParams `json:"params"`
+	Request
+}
+
+type WorkspaceSymbolResponse struct {
+	Response
+	Result []protocol.SymbolInformation `json:"result"`
+}
@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// headerContentLength is the only required header, giving the size of the content in bytes.
+	headerContentLength = "Content-Length"
+
+	// headerContentType is the optional header giving the type and charset of the content.
+	headerContentType = "Content-Type"
+
+	// maxContentLength is the largest content length accepted, far beyond
+	// any real message. Larger lengths are treated as a corrupt header.
+	maxContentLength = 1 << 30
+)
+
+// ErrMalformedMessage is returned for a message that cannot be read, either
+// because its header is corrupt or because its content is not valid JSON.
+//
+// The Reader recovers from malformed messages, so reading can carry on with
+// the next message.
+var ErrMalformedMessage = errors.New("malformed message")
+
+// Reader reads LSP messages from a stream.
+//
+// Each message is a header, made of `Name: value` lines terminated by `\r\n`
+// and followed by an empty line, and then the content. Header names are case
+// insensitive, and unknown headers are ignored. The content can be of any
+// size up to 1GiB, and is read as it arrives, so that memory is only used
+// for bytes actually received rather than for the length announced.
+type Reader struct {
+	r *bufio.Reader
+
+	// pending is a header line found while recovering from a corrupt frame,
+	// to be parsed as the first line of the next header.
+	pending string
+
+	// resync is set after a corrupt frame, when the position of the next
+	// message in the stream is unknown.
+	resync bool
+}
+
+// NewReader returns a Reader that reads messages from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// ReadMessage reads the next message, and returns its method and content.
+//
+// It returns io.EOF when the stream ends between messages, and an error
+// wrapping ErrMalformedMessage when a message cannot be read. Any other error
+// means the stream cannot be read any further.
+func (r *Reader) ReadMessage() (string, []byte, error) {
+	if r.resync {
+		if err := r.skipToHeader(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	length, err := r.readHeader()
+	if err != nil {
+		if errors.Is(err, ErrMalformedMessage) {
+			r.resync = true
+		}
+
+		return "", nil, err
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r.r, int64(length)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", nil, io.ErrUnexpectedEOF
+		}
+
+		return "", nil, err
+	}
+
+	content := buf.Bytes()
+
+	var baseMessage BaseMessage
+	if err := json.Unmarshal(content, &baseMessage); err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrMalformedMessage, err)
+	}
+
+	return baseMessage.Method, content, nil
+}
+
+// readHeader reads the header of a message, and returns its content length.
+func (r *Reader) readHeader() (int, error) {
+	length := -1
+
+	for first := true; ; first = false {
+		line, err := r.readLine()
+		if err != nil {
+			// A stream that ends before a new message starts ends cleanly.
+			if first && errors.Is(err, io.EOF) && line == "" {
+				return 0, io.EOF
+			}
+
+			if errors.Is(err, io.EOF) {
+				return 0, io.ErrUnexpectedEOF
+			}
+
+			return 0, err
+		}
+
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		if !ok || name == "" {
+			return 0, fmt.Errorf("%w: invalid header line %q", ErrMalformedMessage, line)
+		}
+
+		switch {
+		case strings.EqualFold(name, headerContentLength):
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("%w: invalid %s %q", ErrMalformedMessage, headerContentLength, value)
+			}
+
+			if n > maxContentLength {
+				return 0, fmt.Errorf("%w: %s %d is too large", ErrMalformedMessage, headerContentLength, n)
+			}
+
+			length = n
+
+		case strings.EqualFold(name, headerContentType):
+			if err := checkContentType(value); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if length < 0 {
+		return 0, fmt.Errorf("%w: missing %s header", ErrMalformedMessage, headerContentLength)
+	}
+
+	return length, nil
+}
+
+// checkContentType rejects content that is not encoded in UTF-8, the only
+// encoding supported by the LSP spec.
+func checkContentType(value string) error {
+	_, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s %q: %w", ErrMalformedMessage, headerContentType, value, err)
+	}
+
+	// `utf8` is accepted for backwards compatibility, as the spec requires.
+	switch charset := strings.ToLower(params["charset"]); charset {
+	case "", "utf-8", "utf8":
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported charset %q", ErrMalformedMessage, charset)
+	}
+}
+
+// readLine reads a header line, without its line terminator.
+func (r *Reader) readLine() (string, error) {
+	if r.pending != "" {
+		line := r.pending
+		r.pending = ""
+
+		return line, nil
+	}
+
+	line, err := r.r.ReadString('\n')
+
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// skipToHeader discards input until the start of the next message, which is
+// found by looking for a Content-Length header.
+func (r *Reader) skipToHeader() error {
+	for {
+		line, err := r.r.ReadString('\n')
+
+		// The header may directly follow the content of a corrupt message,
+		// on the same line.
+		if i := strings.Index(strings.ToLower(line), strings.ToLower(headerContentLength)+":"); i >= 0 {
+			r.pending = strings.TrimRight(line[i:], "\r\n")
+			r.resync = false
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
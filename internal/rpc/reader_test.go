@@ -0,0 +1,193 @@
+package rpc_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"terragrunt-ls/internal/rpc"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// result is the outcome of a single call to ReadMessage.
+type result struct {
+	method  string
+	content string
+	err     error
+}
+
+// readAll reads messages until the stream ends or cannot be read any further.
+func readAll(t *testing.T, input string) []result {
+	t.Helper()
+
+	reader := rpc.NewReader(strings.NewReader(input))
+
+	var results []result
+
+	for {
+		method, content, err := reader.ReadMessage()
+		if errors.Is(err, io.EOF) {
+			return results
+		}
+
+		results = append(results, result{method: method, content: string(content), err: err})
+
+		if err != nil && !errors.Is(err, rpc.ErrMalformedMessage) {
+			return results
+		}
+	}
+}
+
+func TestReader_ReadMessage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		hello = `{"method":"hello"}`
+		world = `{"method":"world"}`
+	)
+
+	tc := []struct {
+		name     string
+		input    string
+		expected []result
+	}{
+		{
+			name:  "consecutive messages",
+			input: rpc.EncodeMessage(map[string]string{"method": "hello"}) + rpc.EncodeMessage(map[string]string{"method": "world"}),
+			expected: []result{
+				{method: "hello", content: hello},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "content type and case insensitive names",
+			input: "content-type: application/vscode-jsonrpc; charset=utf-8\r\ncontent-length:  18 \r\n\r\n" + hello,
+			expected: []result{
+				{method: "hello", content: hello},
+			},
+		},
+		{
+			name:  "legacy utf8 charset",
+			input: "Content-Length: 18\r\nContent-Type: application/vscode-jsonrpc; charset=utf8\r\n\r\n" + hello,
+			expected: []result{
+				{method: "hello", content: hello},
+			},
+		},
+		{
+			name:  "unknown headers are ignored",
+			input: "X-Trace: abc\r\nContent-Length: 18\r\n\r\n" + hello,
+			expected: []result{
+				{method: "hello", content: hello},
+			},
+		},
+		{
+			name:  "missing content length",
+			input: "Content-Type: application/vscode-jsonrpc\r\n\r\n" + hello + "Content-Length: 18\r\n\r\n" + world,
+			expected: []result{
+				{err: rpc.ErrMalformedMessage},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "invalid content length",
+			input: "Content-Length: lots\r\n\r\n" + hello + "\r\nContent-Length: 18\r\n\r\n" + world,
+			expected: []result{
+				{err: rpc.ErrMalformedMessage},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "impossible content length",
+			input: "Content-Length: 9223372036854775807\r\n\r\n" + hello + "\r\nContent-Length: 18\r\n\r\n" + world,
+			expected: []result{
+				{err: rpc.ErrMalformedMessage},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "garbage before message",
+			input: "garbage\r\n" + rpc.EncodeMessage(map[string]string{"method": "world"}),
+			expected: []result{
+				{err: rpc.ErrMalformedMessage},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "unsupported charset",
+			input: "Content-Length: 18\r\nContent-Type: application/vscode-jsonrpc; charset=latin1\r\n\r\n" + hello + "Content-Length: 18\r\n\r\n" + world,
+			expected: []result{
+				{err: rpc.ErrMalformedMessage},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "invalid json",
+			input: "Content-Length: 9\r\n\r\n{\"method\"" + rpc.EncodeMessage(map[string]string{"method": "world"}),
+			expected: []result{
+				{err: rpc.ErrMalformedMessage},
+				{method: "world", content: world},
+			},
+		},
+		{
+			name:  "truncated content",
+			input: "Content-Length: 100\r\n\r\n" + hello,
+			expected: []result{
+				{err: io.ErrUnexpectedEOF},
+			},
+		},
+		{
+			// Memory must not be reserved for content that never arrives.
+			name:  "truncated large content",
+			input: "Content-Length: 1073741824\r\n\r\n" + hello,
+			expected: []result{
+				{err: io.ErrUnexpectedEOF},
+			},
+		},
+		{
+			name:  "truncated header",
+			input: "Content-Length: 18\r\n",
+			expected: []result{
+				{err: io.ErrUnexpectedEOF},
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := readAll(t, tt.input)
+			require.Len(t, results, len(tt.expected))
+
+			for i, want := range tt.expected {
+				got := results[i]
+
+				if want.err != nil {
+					require.ErrorIs(t, got.err, want.err)
+
+					continue
+				}
+
+				require.NoError(t, got.err)
+				assert.Equal(t, want.method, got.method)
+				assert.Equal(t, want.content, got.content)
+			}
+		})
+	}
+}
+
+func TestReader_LargeMessage(t *testing.T) {
+	t.Parallel()
+
+	// Larger than both the bufio.Reader buffer and the old 64KB scanner limit.
+	text := strings.Repeat("a", 1<<20)
+
+	results := readAll(t, rpc.EncodeMessage(map[string]string{"method": "big", "text": text}))
+	require.Len(t, results, 1)
+
+	require.NoError(t, results[0].err)
+	assert.Equal(t, "big", results[0].method)
+	assert.Contains(t, results[0].content, text)
+}
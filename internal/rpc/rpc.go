@@ -5,9 +5,7 @@ package rpc
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"strconv"
 )
 
 func EncodeMessage(msg any) string {
@@ -23,52 +21,7 @@ type BaseMessage struct {
 	Method string `json:"method"`
 }
 
+// DecodeMessage decodes a single framed message, and returns its method and content.
 func DecodeMessage(msg []byte) (string, []byte, error) {
-	header, content, found := bytes.Cut(msg, []byte{'\r', '\n', '\r', '\n'})
-	if !found {
-		return "", nil, errors.New("did not find separator")
-	}
-
-	// Content-Length: <number>
-	contentLengthBytes := header[len("Content-Length: "):]
-
-	contentLength, err := strconv.Atoi(string(contentLengthBytes))
-	if err != nil {
-		return "", nil, err
-	}
-
-	var baseMessage BaseMessage
-	if err := json.Unmarshal(content[:contentLength], &baseMessage); err != nil {
-		return "", nil, err
-	}
-
-	return baseMessage.Method, content[:contentLength], nil
-}
-
-// Split is a bufio.SplitFunc that splits on the Content-Length header.
-//
-// This is used to split the headers from the payload in LSP requests.
-func Split(data []byte, _ bool) (advance int, token []byte, err error) {
-	header, content, found := bytes.Cut(data, []byte{'\r', '\n', '\r', '\n'})
-	if !found {
-		return 0, nil, nil
-	}
-
-	// Content-Length: <number>
-	contentLengthBytes := header[len("Content-Length: "):]
-
-	contentLength, err := strconv.Atoi(string(contentLengthBytes))
-	if err != nil {
-		return 0, nil, err
-	}
-
-	if len(content) < contentLength {
-		return 0, nil, nil
-	}
-
-	const lenOfTwoRNs = 4
-
-	totalLength := len(header) + lenOfTwoRNs + contentLength
-
-	return totalLength, data[:totalLength], nil
+	return NewReader(bytes.NewReader(msg)).ReadMessage()
 }
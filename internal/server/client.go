@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"terragrunt-ls/internal/lsp"
+
+	"go.lsp.dev/protocol"
+)
+
+var (
+	// ErrCallAborted is returned by calls to the client that cannot be
+	// answered because the session is ending.
+	ErrCallAborted = errors.New("call to client aborted: session is ending")
+
+	// ErrUnsupportedByClient is returned by calls to the client that it did
+	// not declare support for in its capabilities.
+	ErrUnsupportedByClient = errors.New("request not supported by client")
+)
+
+// call sends a request to the client, waits for its response, and decodes
+// the result into result, which may be nil when the result is not needed.
+//
+// An error response from the client is returned as a *lsp.ResponseError.
+// When ctx is cancelled before the client answers, the client is sent
+// `$/cancelRequest` and ctx.Err() is returned.
+//
+// The response is read by the message loop, so call must never be called
+// from the goroutine running Serve.
+func (s *Server) call(ctx context.Context, method string, params any, result any) error {
+	id := lsp.NewNumberID(s.nextCallID.Add(1))
+
+	// Buffered, so that the message loop never blocks on a caller that has
+	// given up waiting.
+	reply := make(chan lsp.ClientResponse, 1)
+
+	s.callsMu.Lock()
+	if s.callsClosed {
+		s.callsMu.Unlock()
+
+		return ErrCallAborted
+	}
+
+	s.calls[id] = reply
+	s.callsMu.Unlock()
+
+	s.l.Debug("Calling client", "method", method, "id", id.String())
+
+	s.writeResponse(lsp.NewClientRequest(id, method, params))
+
+	select {
+	case <-ctx.Done():
+		// A response that arrived as ctx was cancelled still counts, so that
+		// the client is not asked to cancel a request it has answered. Once
+		// the call is no longer pending, the message loop has taken it and
+		// its reply is on its way.
+		if !s.forgetCall(id) {
+			response, ok := <-reply
+
+			return decodeResponse(method, response, ok, result)
+		}
+
+		s.writeResponse(lsp.CancelRequestNotification{
+			Notification: lsp.Notification{
+				RPC:    lsp.RPCVersion,
+				Method: protocol.MethodCancelRequest,
+			},
+			Params: lsp.CancelParams{ID: id},
+		})
+
+		return ctx.Err()
+
+	case response, ok := <-reply:
+		return decodeResponse(method, response, ok, result)
+	}
+}
+
+// decodeResponse decodes the response to a call into result. ok is false
+// when the call was aborted.
+func decodeResponse(method string, response lsp.ClientResponse, ok bool, result any) error {
+	if !ok {
+		return ErrCallAborted
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// forgetCall stops waiting for the response to a call, and reports whether
+// the call was still pending. A call that is no longer pending has been
+// answered or aborted, and its reply channel will receive or be closed.
+func (s *Server) forgetCall(id lsp.ID) bool {
+	s.callsMu.Lock()
+	defer s.callsMu.Unlock()
+
+	_, pending := s.calls[id]
+	delete(s.calls, id)
+
+	return pending
+}
+
+// handleResponse passes a response from the client to the call waiting for it.
+func (s *Server) handleResponse(contents []byte) {
+	var response lsp.ClientResponse
+	if err := json.Unmarshal(contents, &response); err != nil {
+		s.l.Error("Failed to parse response from client", "error", err)
+
+		return
+	}
+
+	s.callsMu.Lock()
+	reply, ok := s.calls[response.ID]
+	delete(s.calls, response.ID)
+	s.callsMu.Unlock()
+
+	if !ok {
+		s.l.Warn("Ignoring response to unknown request", "id", response.ID.String())
+
+		return
+	}
+
+	reply <- response
+}
+
+// abortCalls fails every call still waiting for a response, and any call
+// made afterwards, with ErrCallAborted.
+//
+// It is called before waiting for in-flight requests at the end of the
+// session, as the message loop will not read any more responses.
+func (s *Server) abortCalls() {
+	s.callsMu.Lock()
+	defer s.callsMu.Unlock()
+
+	s.callsClosed = true
+
+	for id, reply := range s.calls {
+		close(reply)
+		delete(s.calls, id)
+	}
+}
+
+// registerCapability dynamically registers capabilities with the client.
+//
+// Callers must check that the client supports dynamic registration of each
+// capability.
+func (s *Server) registerCapability(ctx context.Context, registrations ...protocol.Registration) error {
+	return s.call(ctx, protocol.MethodClientRegisterCapability, protocol.RegistrationParams{Registrations: registrations}, nil)
+}
+
+// createWorkDoneProgress asks the client to create a progress token, which
+// the server can then report progress on with `$/progress`.
+func (s *Server) createWorkDoneProgress(ctx context.Context, token protocol.ProgressToken) error {
+	if window := s.clientCapabilities.Window; window == nil || !window.WorkDoneProgress {
+		return fmt.Errorf("%w: %s", ErrUnsupportedByClient, protocol.MethodWorkDoneProgressCreate)
+	}
+
+	// ProgressToken only marshals through a pointer, so the params must be addressable.
+	return s.call(ctx, protocol.MethodWorkDoneProgressCreate, &protocol.WorkDoneProgressCreateParams{Token: token}, nil)
+}
@@ -0,0 +1,289 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/rpc"
+	"terragrunt-ls/internal/server"
+	"terragrunt-ls/internal/testutils"
+)
+
+// testClient drives an interactive session with a server, for tests that
+// need to answer requests sent by the server.
+type testClient struct {
+	t        *testing.T
+	server   *server.Server
+	input    *io.PipeWriter
+	messages *rpc.Reader
+	exitCode chan int
+}
+
+// startClient starts a session and initializes it with capabilities. Each
+// option is applied to the server before the session starts.
+func startClient(t *testing.T, capabilities message, options ...func(*server.Server)) *testClient {
+	t.Helper()
+
+	c := startSession(t, options...)
+
+	c.send(request("init", "initialize", message{"capabilities": capabilities}))
+	require.Contains(t, c.read(), "result")
+
+	return c
+}
+
+// startSession starts a session without initializing it.
+func startSession(t *testing.T, options ...func(*server.Server)) *testClient {
+	t.Helper()
+
+	inputReader, inputWriter := io.Pipe()
+	outputReader, outputWriter := io.Pipe()
+
+	c := &testClient{
+		t:        t,
+		server:   server.New(testutils.NewTestLogger(t), outputWriter),
+		input:    inputWriter,
+		messages: rpc.NewReader(outputReader),
+		exitCode: make(chan int, 1),
+	}
+
+	for _, option := range options {
+		option(c.server)
+	}
+
+	go func() {
+		c.exitCode <- c.server.Serve(t.Context(), inputReader)
+
+		_ = outputWriter.Close()
+	}()
+
+	t.Cleanup(func() {
+		_ = inputWriter.Close()
+		_ = outputReader.Close()
+	})
+
+	return c
+}
+
+// send writes msg to the server.
+func (c *testClient) send(msg message) {
+	c.t.Helper()
+
+	_, err := c.input.Write([]byte(rpc.EncodeMessage(msg)))
+	require.NoError(c.t, err)
+}
+
+// read reads the next message written by the server.
+func (c *testClient) read() message {
+	c.t.Helper()
+
+	_, contents, err := c.messages.ReadMessage()
+	require.NoError(c.t, err)
+
+	var msg message
+	require.NoError(c.t, json.Unmarshal(contents, &msg))
+
+	return msg
+}
+
+// result is the outcome of a call to the client made in the background.
+type callResult[T any] struct {
+	value T
+	err   error
+}
+
+// inBackground runs fn in its own goroutine, as calls to the client must
+// not block the test that answers them.
+func inBackground[T any](fn func() (T, error)) <-chan callResult[T] {
+	done := make(chan callResult[T], 1)
+
+	go func() {
+		value, err := fn()
+		done <- callResult[T]{value: value, err: err}
+	}()
+
+	return done
+}
+
+// await waits for a call made in the background to return.
+func await[T any](t *testing.T, done <-chan callResult[T]) (T, error) {
+	t.Helper()
+
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "call to client did not return")
+	}
+
+	var zero T
+
+	return zero, nil
+}
+
+// showMessageRequest calls `window/showMessageRequest`, and returns the
+// action chosen by the client.
+func showMessageRequest(t *testing.T, c *testClient, params protocol.ShowMessageRequestParams) (*protocol.MessageActionItem, error) {
+	t.Helper()
+
+	var action *protocol.MessageActionItem
+
+	err := c.server.Call(t.Context(), "window/showMessageRequest", params, &action)
+
+	return action, err
+}
+
+func TestCall_ResponsesAreMatchedToRequests(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{"workspace": message{"configuration": true}})
+
+	config := inBackground(func() ([]json.RawMessage, error) {
+		var settings []json.RawMessage
+
+		err := c.server.Call(t.Context(), "workspace/configuration", protocol.ConfigurationParams{
+			Items: []protocol.ConfigurationItem{{Section: "terragrunt"}},
+		}, &settings)
+
+		return settings, err
+	})
+
+	configRequest := c.read()
+	assert.Equal(t, "workspace/configuration", configRequest["method"])
+	assert.Equal(t, message{"items": []any{map[string]any{"section": "terragrunt"}}}, message(configRequest["params"].(map[string]any)))
+
+	action := inBackground(func() (*protocol.MessageActionItem, error) {
+		return showMessageRequest(t, c, protocol.ShowMessageRequestParams{
+			Message: "Reload?",
+			Type:    protocol.MessageTypeInfo,
+			Actions: []protocol.MessageActionItem{{Title: "Yes"}, {Title: "No"}},
+		})
+	})
+
+	actionRequest := c.read()
+	assert.Equal(t, "window/showMessageRequest", actionRequest["method"])
+	assert.NotEqual(t, configRequest["id"], actionRequest["id"])
+
+	// Responses to unknown requests are ignored.
+	c.send(message{"jsonrpc": "2.0", "id": 1234, "result": nil})
+
+	// Answer out of order.
+	c.send(message{"jsonrpc": "2.0", "id": actionRequest["id"], "result": message{"title": "No"}})
+	c.send(message{"jsonrpc": "2.0", "id": configRequest["id"], "result": []any{message{"logLevel": "debug"}}})
+
+	chosen, err := await(t, action)
+	require.NoError(t, err)
+	require.NotNil(t, chosen)
+	assert.Equal(t, "No", chosen.Title)
+
+	settings, err := await(t, config)
+	require.NoError(t, err)
+	require.Len(t, settings, 1)
+	assert.JSONEq(t, `{"logLevel":"debug"}`, string(settings[0]))
+}
+
+func TestCall_NullResult(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{})
+
+	action := inBackground(func() (*protocol.MessageActionItem, error) {
+		return showMessageRequest(t, c, protocol.ShowMessageRequestParams{Message: "Reload?"})
+	})
+
+	req := c.read()
+	c.send(message{"jsonrpc": "2.0", "id": req["id"], "result": nil})
+
+	chosen, err := await(t, action)
+	require.NoError(t, err)
+	assert.Nil(t, chosen)
+}
+
+func TestCall_ErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{})
+
+	registered := inBackground(func() (struct{}, error) {
+		return struct{}{}, c.server.RegisterCapability(t.Context(), protocol.Registration{
+			ID:     "watch",
+			Method: "workspace/didChangeWatchedFiles",
+		})
+	})
+
+	req := c.read()
+	assert.Equal(t, "client/registerCapability", req["method"])
+
+	c.send(message{"jsonrpc": "2.0", "id": req["id"], "error": message{"code": -32601, "message": "nope"}})
+
+	_, err := await(t, registered)
+
+	var responseErr *lsp.ResponseError
+	require.ErrorAs(t, err, &responseErr)
+	assert.Equal(t, lsp.ErrorCodeMethodNotFound, responseErr.Code)
+	assert.Equal(t, "nope", responseErr.Message)
+}
+
+func TestCall_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{"window": message{"workDoneProgress": true}})
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	created := inBackground(func() (struct{}, error) {
+		return struct{}{}, c.server.CreateWorkDoneProgress(ctx, *protocol.NewProgressToken("indexing"))
+	})
+
+	req := c.read()
+	assert.Equal(t, "window/workDoneProgress/create", req["method"])
+	assert.Equal(t, message{"token": "indexing"}, message(req["params"].(map[string]any)))
+
+	cancel()
+
+	cancellation := c.read()
+	assert.Equal(t, "$/cancelRequest", cancellation["method"])
+	assert.Equal(t, message{"id": req["id"]}, message(cancellation["params"].(map[string]any)))
+
+	_, err := await(t, created)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCall_UnsupportedByClient(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{})
+
+	err := c.server.CreateWorkDoneProgress(t.Context(), *protocol.NewProgressToken("indexing"))
+	require.ErrorIs(t, err, server.ErrUnsupportedByClient)
+}
+
+func TestCall_AbortedOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{})
+
+	action := inBackground(func() (*protocol.MessageActionItem, error) {
+		return showMessageRequest(t, c, protocol.ShowMessageRequestParams{Message: "Reload?"})
+	})
+
+	assert.Equal(t, "window/showMessageRequest", c.read()["method"])
+
+	// The pending call must not hold up shutdown.
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	_, err := await(t, action)
+	require.ErrorIs(t, err, server.ErrCallAborted)
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
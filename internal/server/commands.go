@@ -0,0 +1,66 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/tg/graph"
+)
+
+var (
+	// errNoGraphRoot is returned when the directory to graph is not given,
+	// and there is no single workspace folder to default to.
+	errNoGraphRoot = errors.New("uri is required unless the workspace has a single folder")
+
+	// errTooManyArguments is returned when a command is given more arguments
+	// than it takes.
+	errTooManyArguments = errors.New("too many arguments")
+)
+
+// executeCommand serves `workspace/executeCommand`.
+func (s *Server) executeCommand(id lsp.ID, params lsp.ExecuteCommandParams) any {
+	switch params.Command {
+	case lsp.CommandDependencyGraph:
+		var args lsp.DependencyGraphArguments
+
+		if err := commandArguments(params.Arguments, &args); err != nil {
+			return invalidParams(id, err)
+		}
+
+		format, err := graph.ParseFormat(args.Format)
+		if err != nil {
+			return invalidParams(id, err)
+		}
+
+		var root string
+
+		if args.URI != "" {
+			root = args.URI.Filename()
+		} else {
+			folders := s.state.Folders()
+			if len(folders) != 1 {
+				return invalidParams(id, errNoGraphRoot)
+			}
+
+			root = folders[0]
+		}
+
+		return s.state.DependencyGraph(s.l, id, root, format)
+	}
+
+	s.l.Warn("Command not found", "command", params.Command)
+
+	return lsp.NewErrorResponse(id, lsp.ErrorCodeInvalidParams, "unknown command: "+params.Command)
+}
+
+// commandArguments parses the single, optional argument of a command into args.
+func commandArguments(arguments []json.RawMessage, args any) error {
+	switch len(arguments) {
+	case 0:
+		return nil
+	case 1:
+		return json.Unmarshal(arguments[0], args)
+	}
+
+	return errTooManyArguments
+}
@@ -0,0 +1,87 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/server"
+	"terragrunt-ls/internal/testutils"
+)
+
+func TestCommands_DependencyGraph(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	for _, dir := range []string{"vpc", "app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(root, "vpc"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(root, "app"), "terragrunt.hcl", "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n")
+	require.NoError(t, err)
+
+	c := startSession(t)
+
+	c.send(request("init", "initialize", message{"rootUri": uri.File(root), "capabilities": message{}}))
+
+	reply := c.read()
+	require.Contains(t, reply, "result")
+	assert.Equal(t, map[string]any{
+		"commands": []any{"terragrunt.dependencyGraph"},
+	}, reply["result"].(map[string]any)["capabilities"].(map[string]any)["executeCommandProvider"])
+
+	index := c.server.Index(root)
+
+	c.send(notification("initialized", message{}))
+
+	require.Eventually(t, func() bool {
+		return len(index.Files()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The graph defaults to the workspace folder, in JSON.
+	c.send(request(1, "workspace/executeCommand", message{"command": "terragrunt.dependencyGraph"}))
+
+	assert.Equal(t, map[string]any{
+		"units": []any{
+			map[string]any{"path": "app", "dependencies": []any{"vpc"}},
+			map[string]any{"path": "vpc", "dependencies": []any{}},
+		},
+	}, c.read()["result"])
+
+	c.send(request(2, "workspace/executeCommand", message{
+		"command":   "terragrunt.dependencyGraph",
+		"arguments": []message{{"uri": uri.File(filepath.Join(root, "app")), "format": "dot"}},
+	}))
+
+	assert.Equal(t, "digraph {\n\t\".\";\n\t\"../vpc\";\n\t\".\" -> \"../vpc\";\n}\n", c.read()["result"])
+
+	c.send(request(3, "workspace/executeCommand", message{
+		"command":   "terragrunt.dependencyGraph",
+		"arguments": []message{{"format": "svg"}},
+	}))
+
+	errReply := c.read()
+	require.Contains(t, errReply, "error")
+	assert.InDelta(t, -32602, errReply["error"].(map[string]any)["code"], 0)
+
+	c.send(request(4, "workspace/executeCommand", message{"command": "terragrunt.unknown"}))
+
+	errReply = c.read()
+	require.Contains(t, errReply, "error")
+	assert.Equal(t, "unknown command: terragrunt.unknown", errReply["error"].(map[string]any)["message"])
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
@@ -0,0 +1,301 @@
+package server
+
+import (
+	"context"
+	"runtime/debug"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/tg"
+	"terragrunt-ls/internal/tg/text"
+	"time"
+
+	"go.lsp.dev/protocol"
+)
+
+// defaultDiagnosticsDelay is how long a document must go without changes
+// before it is parsed and its diagnostics are published.
+const defaultDiagnosticsDelay = 200 * time.Millisecond
+
+// document is a document opened by the client.
+//
+// Its text is always the latest sent by the client, while the store in the
+// Terragrunt state holds the last parsed version, which may lag behind while
+// the client is typing.
+type document struct {
+	// timer fires when the document is due to be parsed, and is nil when no
+	// parse is pending.
+	timer *time.Timer
+
+	// cancel cancels the parse of the document that is running, if any.
+	cancel context.CancelFunc
+
+	text string
+
+	// version is the version of text.
+	version int32
+
+	// parsedVersion is the version of the document in the Terragrunt state.
+	parsedVersion int32
+}
+
+// openDocument starts tracking a document opened by the client, parses it
+// straight away, and publishes its diagnostics, so that requests that follow
+// are answered from the content just opened.
+func (s *Server) openDocument(ctx context.Context, docURI protocol.DocumentURI, content string, version int32) {
+	s.docsMu.Lock()
+
+	if d, ok := s.docs[docURI]; ok {
+		d.stop()
+	}
+
+	s.docs[docURI] = &document{
+		text:    content,
+		version: version,
+	}
+
+	s.docsMu.Unlock()
+
+	s.runDiagnostics(ctx, docURI, content, version, false)
+}
+
+// changeDocument applies changes sent by the client to a document, and
+// schedules it to be parsed once the client stops changing it.
+func (s *Server) changeDocument(ctx context.Context, docURI protocol.DocumentURI, version int32, changes []lsp.TextDocumentContentChangeEvent) {
+	s.docsMu.Lock()
+	defer s.docsMu.Unlock()
+
+	d, ok := s.docs[docURI]
+
+	for _, change := range changes {
+		s.l.Debug(
+			"Change",
+			"Range", change.Range,
+			"Text", change.Text,
+		)
+
+		if change.Range == nil {
+			if !ok {
+				d = &document{}
+				s.docs[docURI] = d
+				ok = true
+			}
+
+			d.text = change.Text
+
+			continue
+		}
+
+		if !ok {
+			s.l.Warn(
+				"Ignoring ranged change to unknown document",
+				"URI", docURI,
+			)
+
+			continue
+		}
+
+		d.text = text.ApplyEdit(d.text, *change.Range, change.Text)
+	}
+
+	if !ok {
+		return
+	}
+
+	d.version = version
+
+	s.scheduleDiagnostics(ctx, docURI, d, s.diagnosticsDelay, false)
+}
+
+// saveDocument parses a document saved by the client straight away, and
+// validates it against the filesystem on top of the usual diagnostics.
+func (s *Server) saveDocument(ctx context.Context, docURI protocol.DocumentURI) {
+	s.docsMu.Lock()
+	defer s.docsMu.Unlock()
+
+	d, ok := s.docs[docURI]
+	if !ok {
+		s.l.Warn(
+			"Ignoring save of unknown document",
+			"URI", docURI,
+		)
+
+		return
+	}
+
+	s.scheduleDiagnostics(ctx, docURI, d, 0, true)
+}
+
+// closeDocument stops tracking a document closed by the client, hands it
+// back to the disk, and clears its diagnostics.
+func (s *Server) closeDocument(docURI protocol.DocumentURI) {
+	s.docsMu.Lock()
+	defer s.docsMu.Unlock()
+
+	if d, ok := s.docs[docURI]; ok {
+		d.stop()
+		delete(s.docs, docURI)
+	}
+
+	s.state.CloseDocument(s.l, docURI)
+
+	// Cleared with docsMu held, so that diagnostics of a parse that was
+	// already running cannot be published afterwards.
+	s.publishDiagnostics(docURI, 0, []protocol.Diagnostic{})
+}
+
+// scheduleDiagnostics (re)starts the timer that parses the document after
+// delay, so that a burst of changes is only parsed once. When validate is
+// set, the document is also validated against the filesystem.
+//
+// It must be called with docsMu held.
+func (s *Server) scheduleDiagnostics(ctx context.Context, docURI protocol.DocumentURI, d *document, delay time.Duration, validate bool) {
+	d.stop()
+
+	content, version := d.text, d.version
+
+	d.timer = time.AfterFunc(delay, func() {
+		s.docsMu.Lock()
+
+		current, ok := s.docs[docURI]
+		if s.docsClosed || !ok || current.version != version {
+			s.docsMu.Unlock()
+
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		current.timer = nil
+		current.cancel = cancel
+
+		// Added with docsMu held, so that stopDiagnostics cannot start
+		// waiting in between.
+		s.diagnostics.Add(1)
+		defer s.diagnostics.Done()
+
+		s.docsMu.Unlock()
+
+		s.runDiagnostics(ctx, docURI, content, version, validate)
+	})
+}
+
+// runDiagnostics parses a version of the document, then stores the result
+// and publishes its diagnostics, unless the document has changed meanwhile.
+//
+// A panic while parsing, checking or validating the document drops the run,
+// as it happens outside of the dispatch of any message.
+func (s *Server) runDiagnostics(ctx context.Context, docURI protocol.DocumentURI, content string, version int32, validate bool) {
+	defer s.recoverDiagnosticsPanic(docURI, version)
+
+	st, diagnostics := s.state.ParseDocument(ctx, s.l, docURI, content)
+
+	diagnostics = append(diagnostics, s.state.DependencyCycles(s.l, docURI, st)...)
+
+	if validate {
+		diagnostics = append(diagnostics, tg.ValidateStore(s.l, docURI, st)...)
+	}
+
+	s.docsMu.Lock()
+	defer s.docsMu.Unlock()
+
+	d, ok := s.docs[docURI]
+	if s.docsClosed || !ok || d.version != version {
+		s.l.Debug("Dropping diagnostics of stale document", "URI", docURI, "version", version)
+
+		return
+	}
+
+	s.state.StoreParsedDocument(docURI, st)
+	d.parsedVersion = version
+
+	// Published with docsMu held, so that diagnostics are never published
+	// out of order.
+	s.publishDiagnostics(docURI, version, diagnostics)
+}
+
+// recoverDiagnosticsPanic recovers from a panic while running diagnostics
+// on a version of a document, and logs it.
+//
+// It must be called directly by a deferred statement.
+func (s *Server) recoverDiagnosticsPanic(docURI protocol.DocumentURI, version int32) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	s.l.Error(
+		"Recovered from panic while running diagnostics",
+		"URI", docURI,
+		"version", version,
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+}
+
+// flushDiagnostics parses the document straight away if the Terragrunt state
+// lags behind the client.
+//
+// It is used by requests that edit the document, whose results must match
+// the text the client has.
+func (s *Server) flushDiagnostics(ctx context.Context, docURI protocol.DocumentURI) {
+	s.docsMu.Lock()
+
+	d, ok := s.docs[docURI]
+	if s.docsClosed || !ok || d.parsedVersion == d.version {
+		s.docsMu.Unlock()
+
+		return
+	}
+
+	d.stop()
+
+	content, version := d.text, d.version
+
+	s.docsMu.Unlock()
+
+	s.runDiagnostics(ctx, docURI, content, version, false)
+}
+
+// stopDiagnostics cancels pending and running parses, and waits for them to
+// return. No diagnostics are published afterwards.
+func (s *Server) stopDiagnostics() {
+	s.docsMu.Lock()
+
+	s.docsClosed = true
+
+	for _, d := range s.docs {
+		d.stop()
+	}
+
+	s.docsMu.Unlock()
+
+	s.diagnostics.Wait()
+}
+
+// stop cancels the pending or running parse of the document.
+func (d *document) stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+}
+
+// publishDiagnostics sends the diagnostics of a version of a document to the client.
+func (s *Server) publishDiagnostics(docURI protocol.DocumentURI, version int32, diagnostics []protocol.Diagnostic) {
+	s.writeResponse(lsp.PublishDiagnosticsNotification{
+		Notification: lsp.Notification{
+			RPC:    lsp.RPCVersion,
+			Method: protocol.MethodTextDocumentPublishDiagnostics,
+		},
+		Params: protocol.PublishDiagnosticsParams{
+			URI:         docURI,
+			Version:     uint32(max(version, 0)),
+			Diagnostics: diagnostics,
+		},
+	})
+}
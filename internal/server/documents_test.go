@@ -0,0 +1,174 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/server"
+)
+
+// withDiagnosticsDelay returns an option for startClient that sets the diagnostics delay.
+func withDiagnosticsDelay(delay time.Duration) func(*server.Server) {
+	return func(s *server.Server) {
+		s.SetDiagnosticsDelay(delay)
+	}
+}
+
+// openDocument opens a document and waits for its diagnostics.
+func (c *testClient) openDocument(docURI uri.URI, text string) {
+	c.t.Helper()
+
+	c.send(notification("textDocument/didOpen", message{
+		"textDocument": message{"uri": docURI, "languageId": "terragrunt", "version": 1, "text": text},
+	}))
+
+	published := c.read()
+	require.Equal(c.t, "textDocument/publishDiagnostics", published["method"])
+	assert.InDelta(c.t, 1, published["params"].(map[string]any)["version"], 0)
+}
+
+// replaceDocument sends the full text of a new version of a document.
+func (c *testClient) replaceDocument(docURI uri.URI, version int, text string) {
+	c.t.Helper()
+
+	c.send(notification("textDocument/didChange", message{
+		"textDocument":   message{"uri": docURI, "version": version},
+		"contentChanges": []message{{"text": text}},
+	}))
+}
+
+// hoverValue returns the markdown of a hover over `local.foo` on the third line of a document.
+func (c *testClient) hoverValue(docURI uri.URI, id int) any {
+	c.t.Helper()
+
+	c.send(request(id, "textDocument/hover", message{
+		"textDocument": message{"uri": docURI},
+		"position":     message{"line": 2, "character": 15},
+	}))
+
+	reply := c.read()
+	require.InDelta(c.t, id, reply["id"], 0)
+
+	hover, ok := reply["result"].(map[string]any)
+	require.True(c.t, ok)
+
+	return hover["contents"].(map[string]any)["value"]
+}
+
+func TestDiagnostics_Debounced(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+
+	c := startClient(t, message{}, withDiagnosticsDelay(100*time.Millisecond))
+
+	c.openDocument(docURI, "locals {\n\tfoo = \"bar\"\n\tbar = local.foo\n}\n")
+
+	c.replaceDocument(docURI, 2, "locals {\n\tfoo = \"b\"\n\tbar = local.foo\n}\n")
+	c.replaceDocument(docURI, 3, "locals {\n\tfoo = \"ba\"\n\tbar = local.foo\n}\n")
+	c.replaceDocument(docURI, 4, "locals {\n\tfoo = \"baz\"\n\tbar = local.foo\n}\n")
+
+	// Only the last version of a burst of changes is parsed.
+	published := c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.InDelta(t, 4, published["params"].(map[string]any)["version"], 0)
+
+	// Nothing else is published before the reply.
+	assert.Equal(t, "```hcl\nfoo = \"baz\"\n```", c.hoverValue(docURI, 1))
+}
+
+func TestDiagnostics_LastParsedVersionUntilFlushed(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+
+	c := startClient(t, message{}, withDiagnosticsDelay(time.Hour))
+
+	c.openDocument(docURI, "locals {\n\tfoo = \"bar\"\n\tbar = local.foo\n}\n")
+
+	c.replaceDocument(docURI, 2, "locals {\n\tfoo     = \"baz\"\n\tbar = local.foo\n}\n")
+
+	// Hover keeps answering from the version that was last parsed.
+	assert.Equal(t, "```hcl\nfoo = \"bar\"\n```", c.hoverValue(docURI, 1))
+
+	// Formatting edits the text the client has, so the document is parsed first.
+	c.send(request(2, "textDocument/formatting", message{
+		"textDocument": message{"uri": docURI},
+		"options":      message{"tabSize": 2, "insertSpaces": true},
+	}))
+
+	published := c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.InDelta(t, 2, published["params"].(map[string]any)["version"], 0)
+
+	reply := c.read()
+	require.InDelta(t, 2, reply["id"], 0)
+
+	edits, ok := reply["result"].([]any)
+	require.True(t, ok)
+	require.Len(t, edits, 1)
+	assert.Contains(t, edits[0].(map[string]any)["newText"], "foo = \"baz\"")
+
+	assert.Equal(t, "```hcl\nfoo = \"baz\"\n```", c.hoverValue(docURI, 3))
+}
+
+func TestDiagnostics_SaveValidatesAgainstFilesystem(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "app", "terragrunt.hcl"))
+
+	c := startClient(t, message{}, withDiagnosticsDelay(time.Hour))
+
+	c.openDocument(docURI, "dependency \"db\" {\n  config_path = \"../db\"\n}\n")
+
+	c.send(notification("textDocument/didSave", message{"textDocument": message{"uri": docURI}}))
+
+	published := c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+
+	params := published["params"].(map[string]any)
+	assert.InDelta(t, 1, params["version"], 0)
+
+	diagnostics, ok := params["diagnostics"].([]any)
+	require.True(t, ok)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "Terragrunt", diagnostics[0].(map[string]any)["source"])
+	assert.Contains(t, diagnostics[0].(map[string]any)["message"], "Dependency not found")
+}
+
+func TestDiagnostics_CloseClearsDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+
+	c := startClient(t, message{}, withDiagnosticsDelay(time.Hour))
+
+	c.openDocument(docURI, "locals {\n\tfoo = \"bar\"\n\tbar = local.foo\n}\n")
+
+	// A pending parse is dropped on close.
+	c.replaceDocument(docURI, 2, "locals {\n\tfoo = \n}\n")
+
+	c.send(notification("textDocument/didClose", message{"textDocument": message{"uri": docURI}}))
+
+	published := c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+
+	params := published["params"].(map[string]any)
+	assert.NotContains(t, params, "version")
+	assert.Equal(t, []any{}, params["diagnostics"])
+
+	// Closed documents are no longer served.
+	c.send(request(1, "textDocument/hover", message{
+		"textDocument": message{"uri": docURI},
+		"position":     message{"line": 2, "character": 15},
+	}))
+
+	reply := c.read()
+	require.InDelta(t, 1, reply["id"], 0)
+	assert.Empty(t, reply["result"].(map[string]any)["contents"].(map[string]any)["value"])
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"go.lsp.dev/protocol"
+
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// SetRequestHook installs a hook that is called at the start of every dispatched request.
+func (s *Server) SetRequestHook(hook func(ctx context.Context, method string)) {
+	s.requestHook = hook
+}
+
+// Call exposes call to tests.
+func (s *Server) Call(ctx context.Context, method string, params any, result any) error {
+	return s.call(ctx, method, params, result)
+}
+
+// RegisterCapability exposes registerCapability to tests.
+func (s *Server) RegisterCapability(ctx context.Context, registrations ...protocol.Registration) error {
+	return s.registerCapability(ctx, registrations...)
+}
+
+// CreateWorkDoneProgress exposes createWorkDoneProgress to tests.
+func (s *Server) CreateWorkDoneProgress(ctx context.Context, token protocol.ProgressToken) error {
+	return s.createWorkDoneProgress(ctx, token)
+}
+
+// SetDiagnosticsDelay sets how long a document must go without changes before it is parsed.
+func (s *Server) SetDiagnosticsDelay(delay time.Duration) {
+	s.diagnosticsDelay = delay
+}
+
+// Index exposes the index of the workspace folder of path to tests.
+func (s *Server) Index(path string) *workspace.Index {
+	return s.state.Index(path)
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// indexingToken is the prefix of the progress tokens workspace scans are
+// reported on. Each scan gets a token of its own, as folders added to the
+// workspace are scanned while earlier scans may still be running.
+const indexingToken = "terragrunt-ls/indexing"
+
+// workspaceRoots returns the directories of the workspace sent by the
+// client in `initialize`, preferring workspace folders over the root URI,
+// and the root URI over the deprecated root path.
+func workspaceRoots(params protocol.InitializeParams) []string {
+	if len(params.WorkspaceFolders) > 0 {
+		roots := make([]string, 0, len(params.WorkspaceFolders))
+
+		for _, folder := range params.WorkspaceFolders {
+			roots = append(roots, uri.URI(folder.URI).Filename())
+		}
+
+		return roots
+	}
+
+	if params.RootURI != "" {
+		return []string{params.RootURI.Filename()}
+	}
+
+	if params.RootPath != "" {
+		return []string{params.RootPath}
+	}
+
+	return nil
+}
+
+// startBackground starts the work that runs in the background once the
+// client has confirmed initialization.
+//
+// Watchers are registered before the workspace is scanned, so that no
+// change made during the scan is missed.
+func (s *Server) startBackground(ctx context.Context) {
+	if s.cancelBackground != nil {
+		return
+	}
+
+	ctx, s.cancelBackground = context.WithCancel(ctx)
+	s.backgroundCtx = ctx
+
+	roots := s.state.Folders()
+
+	s.background.Go(func() {
+		s.registerWatchers(ctx)
+
+		if len(roots) == 0 {
+			s.l.Debug("No workspace to index")
+
+			return
+		}
+
+		s.indexWorkspace(ctx, roots)
+	})
+}
+
+// changeWorkspaceFolders adds and removes workspace folders. Added folders
+// are scanned in the background, once the background work has started.
+func (s *Server) changeWorkspaceFolders(event protocol.WorkspaceFoldersChangeEvent) {
+	for _, folder := range event.Removed {
+		root := uri.URI(folder.URI).Filename()
+
+		s.l.Debug("Removing workspace folder", "root", root)
+
+		s.state.RemoveFolder(root)
+	}
+
+	roots := make([]string, 0, len(event.Added))
+
+	for _, folder := range event.Added {
+		root := uri.URI(folder.URI).Filename()
+
+		s.l.Debug("Adding workspace folder", "root", root)
+
+		s.state.AddFolder(root)
+		roots = append(roots, root)
+	}
+
+	if s.backgroundCtx == nil || len(roots) == 0 {
+		return
+	}
+
+	ctx := s.backgroundCtx
+
+	s.background.Go(func() {
+		s.indexWorkspace(ctx, roots)
+	})
+}
+
+// stopBackground cancels the work running in the background, and waits for
+// it to return.
+//
+// Calls to the client must be aborted first, as the work may be waiting on
+// one.
+func (s *Server) stopBackground() {
+	if s.cancelBackground != nil {
+		s.cancelBackground()
+	}
+
+	s.background.Wait()
+}
+
+// indexWorkspace scans each workspace folder into its index. Folders removed
+// in the meantime are skipped.
+func (s *Server) indexWorkspace(ctx context.Context, roots []string) {
+	token := protocol.NewProgressToken(fmt.Sprintf("%s/%d", indexingToken, s.scans.Add(1)))
+
+	err := s.createWorkDoneProgress(ctx, *token)
+	if err != nil && !errors.Is(err, ErrUnsupportedByClient) {
+		s.l.Warn("Failed to create indexing progress", "error", err)
+	}
+
+	report := err == nil
+
+	if report {
+		s.reportProgress(token, &protocol.WorkDoneProgressBegin{
+			Kind:  protocol.WorkDoneProgressKindBegin,
+			Title: "Indexing Terragrunt files",
+		})
+	}
+
+	indexed := 0
+
+	for _, root := range roots {
+		index, ok := s.state.Folder(root)
+		if !ok {
+			continue
+		}
+
+		if s.cacheDir != "" {
+			if build, ok := buildID(); ok {
+				index.SetCache(workspace.NewCache(s.cacheDir, root, build))
+			} else {
+				s.l.Debug("Not caching the index, as the build has no stable identity", "root", root)
+			}
+		}
+
+		var percentage uint32
+
+		err := index.Scan(ctx, s.l, root, func(done, total int) {
+			indexed++
+
+			// Only report when the percentage moves, so that large
+			// workspaces do not flood the client.
+			if current := uint32(done * 100 / total); report && current != percentage {
+				percentage = current
+
+				s.reportProgress(token, &protocol.WorkDoneProgressReport{
+					Kind:       protocol.WorkDoneProgressKindReport,
+					Message:    fmt.Sprintf("%s: %d/%d", root, done, total),
+					Percentage: percentage,
+				})
+			}
+		})
+		if err != nil {
+			s.l.Warn("Failed to index workspace", "root", root, "error", err)
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	s.l.Debug("Finished indexing workspace", "files", indexed)
+
+	if report {
+		s.reportProgress(token, &protocol.WorkDoneProgressEnd{
+			Kind:    protocol.WorkDoneProgressKindEnd,
+			Message: fmt.Sprintf("Indexed %d files", indexed),
+		})
+	}
+}
+
+// reportProgress sends a `$/progress` notification on token.
+func (s *Server) reportProgress(token *protocol.ProgressToken, value any) {
+	s.writeResponse(lsp.ProgressNotification{
+		Notification: lsp.Notification{
+			RPC:    lsp.RPCVersion,
+			Method: protocol.MethodProgress,
+		},
+		Params: &protocol.ProgressParams{
+			Token: *token,
+			Value: value,
+		},
+	})
+}
+
+// buildID returns the identity of the running build of terragrunt-ls, which
+// the index cache is keyed on, and whether it has one.
+//
+// Builds from a clean checkout are identified by their VCS revision, and
+// builds of a released module, such as with `go install`, by its version.
+// Other builds, such as those with local changes, have no stable identity,
+// as a cache written by one could be restored by another that computes
+// different entries.
+func buildID() (string, bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", false
+	}
+
+	var revision string
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				return "", false
+			}
+		}
+	}
+
+	if revision != "" {
+		return revision, true
+	}
+
+	if version := info.Main.Version; version != "" && version != "(devel)" {
+		return version, true
+	}
+
+	return "", false
+}
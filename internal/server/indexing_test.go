@@ -0,0 +1,275 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/server"
+	"terragrunt-ls/internal/testutils"
+)
+
+// createWorkspace creates a workspace with two units, one of which includes
+// a file outside the workspace, and returns the workspace directory.
+func createWorkspace(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "live")
+
+	for _, dir := range []string{"app", "db", filepath.Join("db", ".terragrunt-cache")} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+
+	_, err := testutils.CreateFile(tmpDir, "root.hcl", "")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(root, "app"), "terragrunt.hcl", "include \"root\" {\n  path = find_in_parent_folders(\"root.hcl\")\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(root, "db"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(root, "db", ".terragrunt-cache"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	return root
+}
+
+// params returns the params of a message.
+func params(msg message) message {
+	return message(msg["params"].(map[string]any))
+}
+
+func TestIndexing_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	root := createWorkspace(t)
+
+	c := startSession(t)
+
+	c.send(request("init", "initialize", message{
+		"rootUri":      uri.File(root),
+		"capabilities": message{"window": message{"workDoneProgress": true}},
+	}))
+	require.Contains(t, c.read(), "result")
+
+	c.send(notification("initialized", message{}))
+
+	create := c.read()
+	require.Equal(t, "window/workDoneProgress/create", create["method"])
+
+	token := params(create)["token"]
+
+	c.send(message{"jsonrpc": "2.0", "id": create["id"], "result": nil})
+
+	begin := c.read()
+	require.Equal(t, "$/progress", begin["method"])
+	assert.Equal(t, token, params(begin)["token"])
+	assert.Equal(t, "begin", params(begin)["value"].(map[string]any)["kind"])
+
+	var end message
+
+	for {
+		progress := c.read()
+		require.Equal(t, "$/progress", progress["method"])
+		assert.Equal(t, token, params(progress)["token"])
+
+		value := message(params(progress)["value"].(map[string]any))
+		if value["kind"] == "end" {
+			end = value
+
+			break
+		}
+
+		assert.Equal(t, "report", value["kind"])
+	}
+
+	assert.Equal(t, "Indexed 3 files", end["message"])
+
+	var indexed []string
+	for _, file := range c.server.Index(root).Files() {
+		indexed = append(indexed, file.Path)
+	}
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "app", "terragrunt.hcl"),
+		filepath.Join(root, "db", "terragrunt.hcl"),
+		filepath.Join(filepath.Dir(root), "root.hcl"),
+	}, indexed)
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
+
+func TestIndexing_WorkspaceFolders(t *testing.T) {
+	t.Parallel()
+
+	first, second := createWorkspace(t), createWorkspace(t)
+
+	c := startSession(t)
+
+	// The progress of the scan is not reported to clients that do not
+	// support it.
+	c.send(request("init", "initialize", message{
+		"rootUri": uri.File(t.TempDir()),
+		"workspaceFolders": []message{
+			{"uri": uri.File(first), "name": "first"},
+			{"uri": uri.File(second), "name": "second"},
+		},
+		"capabilities": message{},
+	}))
+	require.Contains(t, c.read(), "result")
+
+	firstIndex, secondIndex := c.server.Index(first), c.server.Index(second)
+	require.NotSame(t, firstIndex, secondIndex)
+
+	c.send(notification("initialized", message{}))
+
+	// The root URI is ignored in favour of the workspace folders, each of
+	// which is indexed on its own.
+	require.Eventually(t, func() bool {
+		return len(firstIndex.Files()) == 3 && len(secondIndex.Files()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
+
+func TestIndexing_StopsOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	root := createWorkspace(t)
+
+	c := startSession(t)
+
+	c.send(request("init", "initialize", message{
+		"rootUri":      uri.File(root),
+		"capabilities": message{"window": message{"workDoneProgress": true}},
+	}))
+	require.Contains(t, c.read(), "result")
+
+	c.send(notification("initialized", message{}))
+
+	assert.Equal(t, "window/workDoneProgress/create", c.read()["method"])
+
+	// The scan waiting on the client must not hold up shutdown.
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
+
+func TestIndexing_Dependents(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	for _, dir := range []string{"vpc", "app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(root, "vpc"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(root, "app"), "terragrunt.hcl", "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n")
+	require.NoError(t, err)
+
+	c := startSession(t)
+
+	c.send(request("init", "initialize", message{"rootUri": uri.File(root), "capabilities": message{}}))
+	require.Contains(t, c.read(), "result")
+
+	index := c.server.Index(root)
+
+	c.send(notification("initialized", message{}))
+
+	require.Eventually(t, func() bool {
+		return len(index.Files()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.send(request(1, "terragrunt/dependents", message{
+		"textDocument": message{"uri": uri.File(filepath.Join(root, "vpc", "terragrunt.hcl"))},
+	}))
+
+	reply := c.read()
+	assert.Equal(t, []any{
+		map[string]any{
+			"uri":  string(uri.File(filepath.Join(root, "app", "terragrunt.hcl"))),
+			"name": "vpc",
+			"range": map[string]any{
+				"start": map[string]any{"line": float64(1), "character": float64(16)},
+				"end":   map[string]any{"line": float64(1), "character": float64(24)},
+			},
+		},
+	}, reply["result"])
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
+
+func TestIndexing_ChangeWorkspaceFolders(t *testing.T) {
+	t.Parallel()
+
+	first, second := createWorkspace(t), createWorkspace(t)
+
+	c := startSession(t)
+
+	c.send(request("init", "initialize", message{
+		"workspaceFolders": []message{{"uri": uri.File(first), "name": "first"}},
+		"capabilities":     message{},
+	}))
+
+	reply := c.read()
+	require.Contains(t, reply, "result")
+	assert.Equal(t, map[string]any{
+		"workspaceFolders": map[string]any{"supported": true, "changeNotifications": true},
+	}, reply["result"].(map[string]any)["capabilities"].(map[string]any)["workspace"])
+
+	firstIndex := c.server.Index(first)
+
+	c.send(notification("initialized", message{}))
+
+	require.Eventually(t, func() bool {
+		return len(firstIndex.Files()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.send(notification("workspace/didChangeWorkspaceFolders", message{
+		"event": message{
+			"added":   []message{{"uri": uri.File(second), "name": "second"}},
+			"removed": []message{{"uri": uri.File(first), "name": "first"}},
+		},
+	}))
+	c.sync()
+
+	secondIndex := c.server.Index(second)
+
+	// Added folders are indexed, and removed folders drop their index.
+	require.Eventually(t, func() bool {
+		return len(secondIndex.Files()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NotSame(t, firstIndex, c.server.Index(first))
+	assert.Empty(t, c.server.Index(first).Files())
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"terragrunt-ls/internal/logger"
+)
+
+const (
+	// schemeTCP prefixes TCP listen addresses, e.g. `tcp://127.0.0.1:7000`.
+	schemeTCP = "tcp://"
+
+	// schemeUnix prefixes Unix domain socket listen addresses, e.g. `unix:///tmp/terragrunt-ls.sock`.
+	schemeUnix = "unix://"
+)
+
+// ErrInvalidAddress is returned when a listen address has no supported scheme.
+var ErrInvalidAddress = errors.New("listen address must start with tcp:// or unix://")
+
+// ParseAddress splits a listen address into the network and address accepted by net.Listen.
+func ParseAddress(addr string) (network, address string, err error) {
+	switch {
+	case strings.HasPrefix(addr, schemeTCP):
+		return "tcp", strings.TrimPrefix(addr, schemeTCP), nil
+	case strings.HasPrefix(addr, schemeUnix):
+		return "unix", strings.TrimPrefix(addr, schemeUnix), nil
+	}
+
+	return "", "", fmt.Errorf("%w: %q", ErrInvalidAddress, addr)
+}
+
+// Listen opens a listener on a `tcp://` or `unix://` address.
+//
+// A Unix socket left behind by a server that did not shut down cleanly is
+// removed, but a socket that still accepts connections is left alone.
+func Listen(addr string) (net.Listener, error) {
+	network, address, err := ParseAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unix" {
+		removeStaleSocket(address)
+	}
+
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	return ln, nil
+}
+
+// removeStaleSocket removes the socket file at path if nothing is listening on it.
+func removeStaleSocket(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		_ = conn.Close()
+
+		return
+	}
+
+	_ = os.Remove(path)
+}
+
+// ServeListener serves one LSP session per connection accepted on ln, until
+// ctx is cancelled or ln fails.
+//
+// When ctx is cancelled, the listener and every open connection are closed,
+// and ServeListener returns once all sessions have ended.
+func ServeListener(ctx context.Context, l logger.Logger, ln net.Listener, opts ...Option) error {
+	var sessions sync.WaitGroup
+	defer sessions.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Closing the listener is the only way to interrupt Accept.
+	stop := context.AfterFunc(ctx, func() {
+		_ = ln.Close()
+	})
+	defer stop()
+
+	l.Info("Listening for connections", "address", ln.Addr().String())
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			_ = ln.Close()
+
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		sessions.Go(func() {
+			serveConn(ctx, l, conn, opts)
+		})
+	}
+}
+
+// serveConn serves a single LSP session over conn, and closes it when the
+// session ends.
+func serveConn(ctx context.Context, l logger.Logger, conn net.Conn, opts []Option) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Closing the connection unblocks the read loop of the session.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	remote := conn.RemoteAddr().String()
+
+	l.Info("Session started", "remote", remote)
+
+	code := New(l, conn, opts...).Serve(ctx, conn)
+
+	l.Info("Session ended", "remote", remote, "code", code)
+}
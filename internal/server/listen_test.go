@@ -0,0 +1,219 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/rpc"
+	"terragrunt-ls/internal/server"
+	"terragrunt-ls/internal/testutils"
+)
+
+func TestParseAddress(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name        string
+		addr        string
+		wantNetwork string
+		wantAddress string
+		wantErr     bool
+	}{
+		{
+			name:        "tcp",
+			addr:        "tcp://127.0.0.1:7000",
+			wantNetwork: "tcp",
+			wantAddress: "127.0.0.1:7000",
+		},
+		{
+			name:        "unix",
+			addr:        "unix:///tmp/terragrunt-ls.sock",
+			wantNetwork: "unix",
+			wantAddress: "/tmp/terragrunt-ls.sock",
+		},
+		{
+			name:    "missing scheme",
+			addr:    "127.0.0.1:7000",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			addr:    "udp://127.0.0.1:7000",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			network, address, err := server.ParseAddress(tt.addr)
+			if tt.wantErr {
+				require.ErrorIs(t, err, server.ErrInvalidAddress)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNetwork, network)
+			assert.Equal(t, tt.wantAddress, address)
+		})
+	}
+}
+
+// startListener serves sessions on ln until the test ends, and returns a
+// channel that receives the result of ServeListener.
+func startListener(t *testing.T, ln net.Listener) (context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.ServeListener(ctx, testutils.NewTestLogger(t), ln)
+	}()
+
+	return cancel, done
+}
+
+// readReply reads the next message the server wrote to the connection read by messages.
+func readReply(t *testing.T, messages *rpc.Reader) message {
+	t.Helper()
+
+	_, contents, err := messages.ReadMessage()
+	require.NoError(t, err, "connection closed before reply")
+
+	var reply message
+	require.NoError(t, json.Unmarshal(contents, &reply))
+
+	return reply
+}
+
+// assertClosed asserts that the server closed the connection read by messages.
+func assertClosed(t *testing.T, messages *rpc.Reader, msg string) {
+	t.Helper()
+
+	_, _, err := messages.ReadMessage()
+	assert.ErrorIs(t, err, io.EOF, msg)
+}
+
+func TestServeListener_SessionPerConnection(t *testing.T) {
+	t.Parallel()
+
+	ln, err := server.Listen("tcp://127.0.0.1:0")
+	require.NoError(t, err)
+
+	cancel, done := startListener(t, ln)
+
+	first, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+
+	defer first.Close()
+
+	second, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+
+	defer second.Close()
+
+	firstReplies := rpc.NewReader(first)
+
+	secondReplies := rpc.NewReader(second)
+
+	// Each connection has its own lifecycle, so initializing one does not
+	// initialize the other.
+	_, err = first.Write([]byte(rpc.EncodeMessage(request(1, "initialize", message{"capabilities": message{}}))))
+	require.NoError(t, err)
+	assert.Contains(t, readReply(t, firstReplies), "result")
+
+	_, err = second.Write([]byte(rpc.EncodeMessage(request(1, "shutdown", nil))))
+	require.NoError(t, err)
+	assert.InDelta(t, -32002, errorCode(t, readReply(t, secondReplies)), 0)
+
+	// Ending one session leaves the others, and the listener, running.
+	_, err = first.Write([]byte(
+		rpc.EncodeMessage(request(2, "shutdown", nil)) +
+			rpc.EncodeMessage(notification("exit", nil)),
+	))
+	require.NoError(t, err)
+	assert.Contains(t, readReply(t, firstReplies), "result")
+	assertClosed(t, firstReplies, "connection should be closed after exit")
+
+	third, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+
+	defer third.Close()
+
+	thirdReplies := rpc.NewReader(third)
+
+	_, err = third.Write([]byte(rpc.EncodeMessage(request(1, "initialize", message{"capabilities": message{}}))))
+	require.NoError(t, err)
+	assert.Contains(t, readReply(t, thirdReplies), "result")
+
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "listener did not shut down")
+	}
+
+	// Open sessions are closed on shutdown.
+	assertClosed(t, secondReplies, "connection should be closed on shutdown")
+	assertClosed(t, thirdReplies, "connection should be closed on shutdown")
+}
+
+func TestServeListener_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	// Socket paths are limited to around 100 bytes, which a test's temporary
+	// directory can exceed.
+	dir, err := os.MkdirTemp("", "tg-ls")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	socket := filepath.Join(dir, "ls.sock")
+
+	// A socket left behind by a previous server is replaced.
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	ln, err := server.Listen("unix://" + socket)
+	require.NoError(t, err)
+
+	cancel, done := startListener(t, ln)
+
+	conn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	replies := rpc.NewReader(conn)
+
+	_, err = conn.Write([]byte(rpc.EncodeMessage(request(1, "initialize", message{"capabilities": message{}}))))
+	require.NoError(t, err)
+	assert.Contains(t, readReply(t, replies), "result")
+
+	cancel()
+	require.NoError(t, <-done)
+
+	_, err = os.Stat(socket)
+	assert.ErrorIs(t, err, os.ErrNotExist, "socket should be removed on shutdown")
+}
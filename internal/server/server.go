@@ -0,0 +1,846 @@
+// Package server provides the message loop of the Terragrunt Language Server.
+//
+// It reads LSP messages from the client, enforces the LSP lifecycle
+// (initialize, shutdown, exit), and dispatches everything else to the
+// Terragrunt state.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/rpc"
+	"terragrunt-ls/internal/tg"
+	"time"
+
+	"go.lsp.dev/protocol"
+)
+
+const (
+	// ExitCodeSuccess is returned when the client sends `exit` after `shutdown`.
+	ExitCodeSuccess = 0
+
+	// ExitCodeFailure is returned when the session ends without a `shutdown` request.
+	ExitCodeFailure = 1
+)
+
+// lifecycle is the state of the LSP session.
+type lifecycle int
+
+const (
+	// lifecycleUninitialized is the state before the `initialize` request.
+	// Only `initialize` and `exit` are accepted.
+	lifecycleUninitialized lifecycle = iota
+
+	// lifecycleInitialized is the state after the `initialize` request.
+	// All requests and notifications are accepted.
+	lifecycleInitialized
+
+	// lifecycleShutdown is the state after the `shutdown` request.
+	// Only `exit` is accepted.
+	lifecycleShutdown
+)
+
+// Server is a single LSP session with a client.
+//
+// Lifecycle messages and notifications are handled in the order they are
+// read, so document updates are always applied in sequence. Requests are
+// served concurrently, each in its own goroutine with a context that is
+// cancelled by `$/cancelRequest`.
+type Server struct {
+	l      logger.Logger
+	writer io.Writer
+	state  *tg.State
+
+	// inflight maps the IDs of requests being served to their cancel functions.
+	inflight map[lsp.ID]context.CancelFunc
+
+	// docs maps the URIs of documents opened by the client, which the editor
+	// owns, to their latest content.
+	docs map[protocol.DocumentURI]*document
+
+	// calls maps the IDs of requests sent to the client to the channels
+	// their responses are delivered on.
+	calls map[lsp.ID]chan lsp.ClientResponse
+
+	// clientCapabilities are the capabilities sent by the client in `initialize`.
+	clientCapabilities protocol.ClientCapabilities
+
+	// backgroundCtx is the context of the work started in the background
+	// once the client confirms initialization, and cancelBackground cancels
+	// it. Both are nil until then.
+	backgroundCtx    context.Context
+	cancelBackground context.CancelFunc
+
+	// requestHook, when set, is called at the start of every dispatched request.
+	// It lets tests hold a request open.
+	requestHook func(ctx context.Context, method string)
+
+	// diagnosticsDelay is how long a document must go without changes before
+	// it is parsed.
+	diagnosticsDelay time.Duration
+
+	// cacheDir is the directory the index of each workspace folder is cached
+	// in, and is empty when the cache is disabled.
+	cacheDir string
+
+	requests    sync.WaitGroup
+	diagnostics sync.WaitGroup
+	background  sync.WaitGroup
+	nextCallID  atomic.Int64
+	scans       atomic.Int64
+	writeMu     sync.Mutex
+	inflightMu  sync.Mutex
+	callsMu     sync.Mutex
+	docsMu      sync.Mutex
+	lifecycle   lifecycle
+	exited      bool
+	callsClosed bool
+	docsClosed  bool
+}
+
+// New creates a new Server that writes its responses to writer.
+func New(l logger.Logger, writer io.Writer, opts ...Option) *Server {
+	s := &Server{
+		l:        l,
+		writer:   writer,
+		state:    tg.NewState(),
+		inflight: map[lsp.ID]context.CancelFunc{},
+		calls:    map[lsp.ID]chan lsp.ClientResponse{},
+		docs:     map[protocol.DocumentURI]*document{},
+
+		diagnosticsDelay: defaultDiagnosticsDelay,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCacheDir caches the index of each workspace folder in dir, so that
+// later sessions only parse the files that changed. The cache is disabled
+// when dir is empty, which is the default.
+func WithCacheDir(dir string) Option {
+	return func(s *Server) {
+		s.cacheDir = dir
+	}
+}
+
+// Serve reads messages from reader until the client sends `exit` or the
+// stream ends, and returns the exit code the process should terminate with.
+func (s *Server) Serve(ctx context.Context, reader io.Reader) int {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages := rpc.NewReader(reader)
+
+	for {
+		method, contents, err := messages.ReadMessage()
+		if errors.Is(err, rpc.ErrMalformedMessage) {
+			s.l.Error("Got an error decoding message from client", "err", err)
+
+			// The ID of a message that cannot be decoded is unknown, so the
+			// spec requires the error to be sent with a null ID.
+			s.writeResponse(lsp.NewErrorResponse(lsp.NullID(), lsp.ErrorCodeParseError, err.Error()))
+
+			continue
+		}
+
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.l.Error("Failed to read message from client", "error", err)
+			}
+
+			break
+		}
+
+		s.handleMessage(ctx, method, contents)
+
+		if s.exited {
+			break
+		}
+	}
+
+	// Let requests still being served write their answers before returning.
+	// No more responses will be read, so they cannot wait on the client.
+	s.abortCalls()
+	s.stopBackground()
+	s.requests.Wait()
+	s.stopDiagnostics()
+
+	return s.exitCode()
+}
+
+// exitCode returns the exit code required by the LSP spec: success only when
+// the session was shut down before it ended.
+func (s *Server) exitCode() int {
+	if s.lifecycle == lifecycleShutdown {
+		return ExitCodeSuccess
+	}
+
+	return ExitCodeFailure
+}
+
+// envelope holds the parts of a message needed to apply the lifecycle rules.
+type envelope struct {
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+
+	// ID is not set for notifications.
+	ID lsp.ID `json:"id"`
+}
+
+// isResponse reports whether the message is a response to a request sent to the client.
+func (e envelope) isResponse() bool {
+	return e.ID.IsSet() && (e.Result != nil || e.Error != nil)
+}
+
+// allowed reports whether the message may be handled in the current lifecycle
+// state. When it may not and the message is a request, an error response is sent.
+func (s *Server) allowed(method string, env envelope) bool {
+	if method == protocol.MethodExit {
+		return true
+	}
+
+	switch s.lifecycle {
+	case lifecycleUninitialized:
+		if method == protocol.MethodInitialize {
+			return true
+		}
+
+		s.l.Warn("Dropping message received before initialize", "method", method)
+
+		if env.ID.IsSet() {
+			s.writeResponse(lsp.NewErrorResponse(env.ID, lsp.ErrorCodeServerNotInitialized, "server not initialized"))
+		}
+
+		return false
+
+	case lifecycleInitialized:
+		if method != protocol.MethodInitialize {
+			return true
+		}
+
+		s.l.Warn("Rejecting repeated initialize request")
+
+		if env.ID.IsSet() {
+			s.writeResponse(lsp.NewErrorResponse(env.ID, lsp.ErrorCodeInvalidRequest, "server is already initialized"))
+		}
+
+		return false
+
+	case lifecycleShutdown:
+		s.l.Warn("Dropping message received after shutdown", "method", method)
+
+		if env.ID.IsSet() {
+			s.writeResponse(lsp.NewErrorResponse(env.ID, lsp.ErrorCodeInvalidRequest, "server is shutting down"))
+		}
+
+		return false
+	}
+
+	return false
+}
+
+func (s *Server) handleMessage(ctx context.Context, method string, contents []byte) {
+	s.l.Debug("Received msg", "method", method, "contents", string(contents))
+
+	var env envelope
+	if err := json.Unmarshal(contents, &env); err != nil {
+		s.l.Error("Failed to parse message envelope", "method", method, "error", err)
+
+		s.writeResponse(lsp.NewErrorResponse(lsp.NullID(), lsp.ErrorCodeInvalidRequest, err.Error()))
+
+		return
+	}
+
+	if method == "" && env.isResponse() {
+		s.handleResponse(contents)
+
+		return
+	}
+
+	if method == "" {
+		s.l.Error("Received message without a method", "id", env.ID.String())
+
+		if env.ID.IsSet() {
+			s.writeResponse(lsp.NewErrorResponse(env.ID, lsp.ErrorCodeInvalidRequest, "message has no method"))
+		}
+
+		return
+	}
+
+	// A panic while handling one message must not take the whole session down.
+	defer s.recoverPanic(method, env.ID)
+
+	if !s.allowed(method, env) {
+		return
+	}
+
+	switch method {
+	case protocol.MethodInitialize:
+		var request lsp.InitializeRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error("Failed to parse initialize request", "err", err)
+
+			s.writeResponse(invalidParams(env.ID, err))
+
+			return
+		}
+
+		s.clientCapabilities = request.Params.Capabilities
+		for _, root := range workspaceRoots(request.Params) {
+			s.state.AddFolder(root)
+		}
+
+		if clientInfo := request.Params.ClientInfo; clientInfo != nil {
+			s.l.Debug("Connected",
+				"Name", clientInfo.Name,
+				"Version", clientInfo.Version)
+		}
+
+		msg := lsp.NewInitializeResponse(request.ID)
+		s.writeResponse(msg)
+
+		s.lifecycle = lifecycleInitialized
+
+		s.l.Debug("Initialized")
+
+	case protocol.MethodInitialized:
+		s.l.Debug("Client confirmed initialization")
+
+		s.startBackground(ctx)
+
+	case protocol.MethodShutdown:
+		var request lsp.ShutdownRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error("Failed to parse shutdown request", "err", err)
+
+			s.writeResponse(invalidParams(env.ID, err))
+
+			return
+		}
+
+		// Nothing is served after shutdown, so once in-flight requests are
+		// answered, the parsed configs can be released. Shutdown holds up the
+		// message loop, so requests cannot wait on the client meanwhile.
+		s.abortCalls()
+		s.stopBackground()
+		s.requests.Wait()
+		s.stopDiagnostics()
+
+		s.state = nil
+		s.lifecycle = lifecycleShutdown
+
+		s.writeResponse(lsp.NewShutdownResponse(request.ID))
+
+		s.l.Debug("Shut down")
+
+	case protocol.MethodExit:
+		s.exited = true
+
+		s.l.Debug("Exiting", "code", s.exitCode())
+
+	case protocol.MethodCancelRequest:
+		var notification lsp.CancelRequestNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse cancel request",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.cancelRequest(notification.Params.ID)
+
+	case protocol.MethodTextDocumentDidOpen:
+		var notification lsp.DidOpenTextDocumentNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse didOpen notification",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.l.Debug(
+			"Opened",
+			"URI", notification.Params.TextDocument.URI,
+			"LanguageID", notification.Params.TextDocument.LanguageID,
+			"Version", notification.Params.TextDocument.Version,
+			"Text", notification.Params.TextDocument.Text,
+		)
+
+		s.openDocument(ctx, notification.Params.TextDocument.URI, notification.Params.TextDocument.Text, notification.Params.TextDocument.Version)
+
+		s.l.Debug(
+			"Document opened",
+			"URI", notification.Params.TextDocument.URI,
+		)
+
+	case protocol.MethodTextDocumentDidChange:
+		var notification lsp.DidChangeTextDocumentNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse didChange notification",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.l.Debug(
+			"Changed",
+			"URI", notification.Params.TextDocument.URI,
+			"Changes", notification.Params.ContentChanges,
+		)
+
+		s.changeDocument(ctx, notification.Params.TextDocument.URI, notification.Params.TextDocument.Version, notification.Params.ContentChanges)
+
+		s.l.Debug(
+			"Document changed",
+			"URI", notification.Params.TextDocument.URI,
+			"Version", notification.Params.TextDocument.Version,
+		)
+
+	case protocol.MethodTextDocumentDidSave:
+		var notification lsp.DidSaveTextDocumentNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse didSave notification",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.l.Debug(
+			"Saved",
+			"URI", notification.Params.TextDocument.URI,
+		)
+
+		s.saveDocument(ctx, notification.Params.TextDocument.URI)
+
+	case protocol.MethodTextDocumentDidClose:
+		var notification lsp.DidCloseTextDocumentNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse didClose notification",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.l.Debug(
+			"Closed",
+			"URI", notification.Params.TextDocument.URI,
+		)
+
+		s.closeDocument(notification.Params.TextDocument.URI)
+
+	case protocol.MethodWorkspaceDidChangeWatchedFiles:
+		var notification lsp.DidChangeWatchedFilesNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse didChangeWatchedFiles notification",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.changeWatchedFiles(ctx, notification.Params.Changes)
+
+	case protocol.MethodWorkspaceDidChangeWorkspaceFolders:
+		var notification lsp.DidChangeWorkspaceFoldersNotification
+		if err := json.Unmarshal(contents, &notification); err != nil {
+			s.l.Error(
+				"Failed to parse didChangeWorkspaceFolders notification",
+				"error",
+				err,
+			)
+
+			return
+		}
+
+		s.changeWorkspaceFolders(notification.Params.Event)
+
+	default:
+		// Notifications are never answered, not even with an error.
+		if !env.ID.IsSet() {
+			s.l.Debug("Ignoring notification", "method", method)
+
+			return
+		}
+
+		s.dispatch(ctx, env.ID, method, contents)
+	}
+}
+
+// dispatch serves a request in its own goroutine, so that slow requests do
+// not hold up the ones read after them.
+func (s *Server) dispatch(ctx context.Context, id lsp.ID, method string, contents []byte) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	s.inflightMu.Lock()
+	s.inflight[id] = cancel
+	s.inflightMu.Unlock()
+
+	s.requests.Go(func() {
+		defer s.finishRequest(id)
+		defer s.recoverPanic(method, id)
+
+		if s.requestHook != nil {
+			s.requestHook(ctx, method)
+		}
+
+		response := s.handleRequest(ctx, id, method, contents)
+
+		// The result of a cancelled request may be stale, so the client is
+		// told it was cancelled instead.
+		if ctx.Err() != nil {
+			s.l.Debug("Request cancelled", "method", method, "id", id.String())
+
+			response = lsp.NewErrorResponse(id, lsp.ErrorCodeRequestCancelled, "request cancelled")
+		}
+
+		s.writeResponse(response)
+	})
+}
+
+// finishRequest forgets a request once it has been answered.
+func (s *Server) finishRequest(id lsp.ID) {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+
+	if cancel, ok := s.inflight[id]; ok {
+		cancel()
+		delete(s.inflight, id)
+	}
+}
+
+// cancelRequest cancels the context of an in-flight request. Requests that
+// have already been answered are ignored.
+func (s *Server) cancelRequest(id lsp.ID) {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+
+	cancel, ok := s.inflight[id]
+	if !ok {
+		s.l.Debug("Ignoring cancellation of unknown request", "id", id.String())
+
+		return
+	}
+
+	cancel()
+}
+
+// recoverPanic recovers from a panic while handling a message, logs it, and
+// answers the request with an internal error when id is set.
+//
+// It must be called directly by a deferred statement.
+func (s *Server) recoverPanic(method string, id lsp.ID) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	s.l.Error(
+		"Recovered from panic while handling message",
+		"method", method,
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+
+	if id.IsSet() {
+		s.writeResponse(lsp.NewErrorResponse(id, lsp.ErrorCodeInternalError, fmt.Sprintf("internal error: %v", r)))
+	}
+}
+
+// invalidParams builds the error response for a request whose params could not be parsed.
+func invalidParams(id lsp.ID, err error) lsp.ErrorResponse {
+	return lsp.NewErrorResponse(id, lsp.ErrorCodeInvalidParams, err.Error())
+}
+
+// handleRequest serves a single request and returns the response to send.
+func (s *Server) handleRequest(ctx context.Context, id lsp.ID, method string, contents []byte) any {
+	switch method {
+	case protocol.MethodTextDocumentHover:
+		var request lsp.HoverRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Debug(
+				"Failed to parse hover request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Hover",
+			"URI", request.Params.TextDocument.URI,
+			"Position", request.Params.Position,
+		)
+
+		return s.state.Hover(s.l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
+
+	case protocol.MethodTextDocumentDefinition:
+		var request lsp.DefinitionRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse definition request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Definition",
+			"URI", request.Params.TextDocument.URI,
+			"Position", request.Params.Position,
+		)
+
+		return s.state.Definition(s.l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
+
+	case protocol.MethodTextDocumentCompletion:
+		var request lsp.CompletionRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse completion request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Completion",
+			"URI", request.Params.TextDocument.URI,
+			"Position", request.Params.Position,
+		)
+
+		response := s.state.TextDocumentCompletion(s.l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
+
+		s.l.Debug(
+			"Completion response",
+			"Response", response,
+		)
+
+		return response
+
+	case protocol.MethodTextDocumentFormatting:
+		var request lsp.FormatRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse format request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		// The edits must apply to the text the client has, not to the last
+		// version that was parsed.
+		s.flushDiagnostics(ctx, request.Params.TextDocument.URI)
+
+		s.l.Debug(
+			"Formatting",
+			"URI", request.Params.TextDocument.URI,
+		)
+
+		return s.state.TextDocumentFormatting(s.l, request.ID, request.Params.TextDocument.URI)
+
+	case protocol.MethodTextDocumentReferences:
+		var request lsp.ReferencesRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse references request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"References",
+			"URI", request.Params.TextDocument.URI,
+			"Position", request.Params.Position,
+			"IncludeDeclaration", request.Params.Context.IncludeDeclaration,
+		)
+
+		return s.state.TextDocumentReferences(s.l, request.ID, request.Params.TextDocument.URI, request.Params.Position, request.Params.Context.IncludeDeclaration)
+
+	case lsp.MethodDependents:
+		var request lsp.DependentsRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse dependents request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Dependents",
+			"URI", request.Params.TextDocument.URI,
+		)
+
+		return s.state.Dependents(s.l, request.ID, request.Params.TextDocument.URI)
+
+	case protocol.MethodTextDocumentDocumentSymbol:
+		var request lsp.DocumentSymbolRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse document symbol request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Document symbol",
+			"URI", request.Params.TextDocument.URI,
+		)
+
+		return s.state.TextDocumentSymbols(s.l, request.ID, request.Params.TextDocument.URI)
+
+	case protocol.MethodWorkspaceSymbol:
+		var request lsp.WorkspaceSymbolRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse workspace symbol request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Workspace symbol",
+			"Query", request.Params.Query,
+		)
+
+		return s.state.WorkspaceSymbols(s.l, request.ID, request.Params.Query)
+
+	case protocol.MethodWorkspaceExecuteCommand:
+		var request lsp.ExecuteCommandRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse execute command request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		s.l.Debug(
+			"Execute command",
+			"Command", request.Params.Command,
+		)
+
+		return s.executeCommand(request.ID, request.Params)
+
+	case protocol.MethodTextDocumentPrepareRename:
+		var request lsp.PrepareRenameRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse prepare rename request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		// The edits must apply to the text the client has, not to the last
+		// version that was parsed.
+		s.flushDiagnostics(ctx, request.Params.TextDocument.URI)
+
+		s.l.Debug(
+			"Prepare rename",
+			"URI", request.Params.TextDocument.URI,
+			"Position", request.Params.Position,
+		)
+
+		return s.state.PrepareRename(s.l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
+
+	case protocol.MethodTextDocumentRename:
+		var request lsp.RenameRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			s.l.Error(
+				"Failed to parse rename request",
+				"error",
+				err,
+			)
+
+			return invalidParams(id, err)
+		}
+
+		// The edits must apply to the text the client has, not to the last
+		// version that was parsed.
+		s.flushDiagnostics(ctx, request.Params.TextDocument.URI)
+
+		s.l.Debug(
+			"Rename",
+			"URI", request.Params.TextDocument.URI,
+			"Position", request.Params.Position,
+			"NewName", request.Params.NewName,
+		)
+
+		return s.state.TextDocumentRename(s.l, request.ID, request.Params.TextDocument.URI, request.Params.Position, request.Params.NewName)
+	}
+
+	s.l.Warn("Method not found", "method", method)
+
+	return lsp.NewErrorResponse(id, lsp.ErrorCodeMethodNotFound, "method not found: "+method)
+}
+
+func (s *Server) writeResponse(msg any) {
+	reply := rpc.EncodeMessage(msg)
+
+	// Responses are written from concurrent requests, and must not interleave.
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_, err := s.writer.Write([]byte(reply))
+	if err != nil {
+		s.l.Error(
+			"Failed to write response",
+			"error",
+			err,
+		)
+	}
+}
@@ -0,0 +1,499 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/rpc"
+	"terragrunt-ls/internal/server"
+	"terragrunt-ls/internal/testutils"
+)
+
+// message is a generic JSON-RPC message, used both to build client input and
+// to inspect server output.
+type message map[string]any
+
+func request(id any, method string, params any) message {
+	return message{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notification(method string, params any) message {
+	return message{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+// runSession feeds msgs to a fresh server and returns its exit code along with
+// every message it wrote back.
+func runSession(t *testing.T, msgs ...message) (int, []message) {
+	t.Helper()
+
+	return runSessionWithHook(t, nil, msgs...)
+}
+
+// runSessionWithHook is like runSession, but calls hook at the start of every
+// dispatched request.
+func runSessionWithHook(t *testing.T, hook func(ctx context.Context, method string), msgs ...message) (int, []message) {
+	t.Helper()
+
+	var input strings.Builder
+	for _, msg := range msgs {
+		input.WriteString(rpc.EncodeMessage(msg))
+	}
+
+	var output bytes.Buffer
+
+	s := server.New(testutils.NewTestLogger(t), &output)
+	s.SetRequestHook(hook)
+
+	code := s.Serve(t.Context(), strings.NewReader(input.String()))
+
+	return code, decodeReplies(t, &output)
+}
+
+// decodeReplies decodes every message the server wrote to output.
+func decodeReplies(t *testing.T, output io.Reader) []message {
+	t.Helper()
+
+	messages := rpc.NewReader(output)
+
+	var replies []message
+
+	for {
+		_, contents, err := messages.ReadMessage()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		var reply message
+		require.NoError(t, json.Unmarshal(contents, &reply))
+
+		replies = append(replies, reply)
+	}
+
+	return replies
+}
+
+func errorCode(t *testing.T, reply message) float64 {
+	t.Helper()
+
+	errObj, ok := reply["error"].(map[string]any)
+	require.True(t, ok, "expected an error response, got %v", reply)
+
+	code, ok := errObj["code"].(float64)
+	require.True(t, ok)
+
+	return code
+}
+
+func TestServe_FullSession(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+
+	code, replies := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		notification("initialized", message{}),
+		notification("textDocument/didOpen", message{
+			"textDocument": message{
+				"uri":        docURI,
+				"languageId": "terragrunt",
+				"version":    1,
+				"text":       "locals {\n\tfoo = \"bar\"\n\tbar = local.foo\n}\n",
+			},
+		}),
+		request(2, "textDocument/hover", message{
+			"textDocument": message{"uri": docURI},
+			"position":     message{"line": 2, "character": 15},
+		}),
+		request(3, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+	require.Len(t, replies, 4)
+
+	assert.InDelta(t, 1, replies[0]["id"], 0)
+
+	initResult, ok := replies[0]["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"openClose": true, "change": float64(2), "save": map[string]any{}}, initResult["capabilities"].(map[string]any)["textDocumentSync"])
+
+	assert.Equal(t, "textDocument/publishDiagnostics", replies[1]["method"])
+
+	assert.InDelta(t, 2, replies[2]["id"], 0)
+	assert.Contains(t, replies[2]["result"], "contents")
+
+	assert.InDelta(t, 3, replies[3]["id"], 0)
+	assert.Contains(t, replies[3], "result")
+	assert.Nil(t, replies[3]["result"])
+	assert.NotContains(t, replies[3], "error")
+}
+
+func TestServe_RequestBeforeInitialize(t *testing.T) {
+	t.Parallel()
+
+	code, replies := runSession(t,
+		request(1, "textDocument/hover", message{
+			"textDocument": message{"uri": "file:///foo/terragrunt.hcl"},
+			"position":     message{"line": 0, "character": 0},
+		}),
+		notification("textDocument/didOpen", message{
+			"textDocument": message{"uri": "file:///foo/terragrunt.hcl", "text": ""},
+		}),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeFailure, code)
+	require.Len(t, replies, 1)
+
+	assert.InDelta(t, 1, replies[0]["id"], 0)
+	assert.InDelta(t, -32002, errorCode(t, replies[0]), 0)
+}
+
+func TestServe_RepeatedInitialize(t *testing.T) {
+	t.Parallel()
+
+	_, replies := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		request(2, "initialize", message{"capabilities": message{}}),
+	)
+
+	require.Len(t, replies, 2)
+	assert.InDelta(t, -32600, errorCode(t, replies[1]), 0)
+}
+
+func TestServe_RequestAfterShutdown(t *testing.T) {
+	t.Parallel()
+
+	code, replies := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		request(2, "shutdown", nil),
+		request(3, "textDocument/completion", message{
+			"textDocument": message{"uri": "file:///foo/terragrunt.hcl"},
+			"position":     message{"line": 0, "character": 0},
+		}),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+	require.Len(t, replies, 3)
+
+	assert.InDelta(t, 3, replies[2]["id"], 0)
+	assert.InDelta(t, -32600, errorCode(t, replies[2]), 0)
+}
+
+func TestServe_ExitWithoutShutdown(t *testing.T) {
+	t.Parallel()
+
+	code, _ := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeFailure, code)
+}
+
+func TestServe_StreamEndsWithoutExit(t *testing.T) {
+	t.Parallel()
+
+	code, _ := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+	)
+
+	assert.Equal(t, server.ExitCodeFailure, code)
+}
+
+func TestServe_StopsReadingAfterExit(t *testing.T) {
+	t.Parallel()
+
+	_, replies := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		request(2, "shutdown", nil),
+		notification("exit", nil),
+		request(3, "initialize", message{"capabilities": message{}}),
+	)
+
+	assert.Len(t, replies, 2)
+}
+
+func TestServe_IncrementalChange(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+
+	c := startClient(t, message{}, func(s *server.Server) {
+		s.SetDiagnosticsDelay(time.Millisecond)
+	})
+
+	c.send(notification("textDocument/didOpen", message{
+		"textDocument": message{
+			"uri":        docURI,
+			"languageId": "terragrunt",
+			"version":    1,
+			"text":       "locals {\n\tfoo = \"bar\"\n\tbar = local.foo\n}\n",
+		},
+	}))
+	assert.Equal(t, "textDocument/publishDiagnostics", c.read()["method"])
+
+	c.send(notification("textDocument/didChange", message{
+		"textDocument": message{"uri": docURI, "version": 2},
+		"contentChanges": []message{
+			{
+				"range": message{
+					"start": message{"line": 1, "character": 8},
+					"end":   message{"line": 1, "character": 11},
+				},
+				"text": "baz",
+			},
+			{
+				"range": message{
+					"start": message{"line": 1, "character": 11},
+					"end":   message{"line": 1, "character": 11},
+				},
+				"text": "🚀",
+			},
+		},
+	}))
+
+	published := c.read()
+	assert.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.InDelta(t, 2, published["params"].(map[string]any)["version"], 0)
+
+	c.send(request(2, "textDocument/hover", message{
+		"textDocument": message{"uri": docURI},
+		"position":     message{"line": 2, "character": 15},
+	}))
+
+	hover, ok := c.read()["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "```hcl\nfoo = \"baz🚀\"\n```", hover["contents"].(map[string]any)["value"])
+}
+
+// findReply returns the reply with the given id.
+func findReply(t *testing.T, replies []message, id any) message {
+	t.Helper()
+
+	// Numeric IDs come back from JSON as float64.
+	if n, ok := id.(int); ok {
+		id = float64(n)
+	}
+
+	for _, reply := range replies {
+		if replyID, ok := reply["id"]; ok && replyID == id {
+			return reply
+		}
+	}
+
+	require.Failf(t, "reply not found", "no reply with id %v in %v", id, replies)
+
+	return nil
+}
+
+func TestServe_ConcurrentRequestsAndCancellation(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	completionStarted := make(chan struct{})
+
+	hook := func(ctx context.Context, method string) {
+		switch method {
+		case "textDocument/completion":
+			close(completionStarted)
+		case "textDocument/hover":
+			// If requests were served one at a time, the completion would
+			// never start while the hover is held open.
+			select {
+			case <-completionStarted:
+			case <-time.After(5 * time.Second):
+				t.Error("completion was not served while hover was in flight")
+			}
+
+			<-ctx.Done()
+		}
+	}
+
+	position := message{"line": 0, "character": 0}
+
+	code, replies := runSessionWithHook(t, hook,
+		request(1, "initialize", message{"capabilities": message{}}),
+		notification("textDocument/didOpen", message{
+			"textDocument": message{"uri": docURI, "version": 1, "text": "locals {}\n"},
+		}),
+		request(2, "textDocument/hover", message{"textDocument": message{"uri": docURI}, "position": position}),
+		request(3, "textDocument/completion", message{"textDocument": message{"uri": docURI}, "position": position}),
+		notification("$/cancelRequest", message{"id": 2}),
+		notification("$/cancelRequest", message{"id": 42}),
+		request(4, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+	require.Len(t, replies, 5)
+
+	assert.InDelta(t, -32800, errorCode(t, findReply(t, replies, 2)), 0)
+	assert.Contains(t, findReply(t, replies, 3), "result")
+
+	// Shutdown waits for in-flight requests, so it is always answered last.
+	assert.InDelta(t, 4, replies[4]["id"], 0)
+}
+
+func TestServe_StringAndNullIDs(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	position := message{"line": 0, "character": 0}
+
+	hook := func(ctx context.Context, method string) {
+		if method == "textDocument/hover" {
+			<-ctx.Done()
+		}
+	}
+
+	code, replies := runSessionWithHook(t, hook,
+		request("init", "initialize", message{"capabilities": message{}}),
+		notification("textDocument/didOpen", message{
+			"textDocument": message{"uri": docURI, "version": 1, "text": "locals {}\n"},
+		}),
+		request("hover-1", "textDocument/hover", message{"textDocument": message{"uri": docURI}, "position": position}),
+		notification("$/cancelRequest", message{"id": "hover-1"}),
+		request(nil, "textDocument/completion", message{"textDocument": message{"uri": docURI}, "position": position}),
+		request("bye", "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+	require.Len(t, replies, 5)
+
+	assert.Contains(t, findReply(t, replies, "init"), "result")
+	assert.InDelta(t, -32800, errorCode(t, findReply(t, replies, "hover-1")), 0)
+
+	assert.Contains(t, findReply(t, replies, nil), "result")
+
+	assert.Equal(t, "bye", replies[4]["id"])
+}
+
+// rawFrame builds a frame around content, which need not be valid JSON.
+func rawFrame(content string) string {
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(content), content)
+}
+
+func TestServe_ParseError(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+
+	s := server.New(testutils.NewTestLogger(t), &output)
+
+	input := rawFrame(`{"jsonrpc": "2.0", "id": 1, "method": "initialize"`) +
+		rpc.EncodeMessage(request(2, "initialize", message{"capabilities": message{}}))
+
+	s.Serve(t.Context(), strings.NewReader(input))
+
+	replies := decodeReplies(t, &output)
+
+	require.Len(t, replies, 2)
+
+	assert.Contains(t, replies[0], "id")
+	assert.Nil(t, replies[0]["id"])
+	assert.InDelta(t, -32700, errorCode(t, replies[0]), 0)
+
+	// The session carries on after a bad message.
+	assert.InDelta(t, 2, replies[1]["id"], 0)
+	assert.Contains(t, replies[1], "result")
+}
+
+func TestServe_ErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	code, replies := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		request(2, "textDocument/hover", message{"position": "not a position"}),
+		request(3, "textDocument/unknown", message{}),
+		notification("textDocument/didOpen", message{"textDocument": "not a document"}),
+		notification("$/unknownNotification", message{}),
+		notification("workspace/unknown", message{}),
+		request(4, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+
+	// Notifications are never answered, even when they fail.
+	require.Len(t, replies, 4)
+
+	assert.InDelta(t, -32602, errorCode(t, findReply(t, replies, 2)), 0)
+	assert.InDelta(t, -32601, errorCode(t, findReply(t, replies, 3)), 0)
+}
+
+func TestServe_InternalError(t *testing.T) {
+	t.Parallel()
+
+	hook := func(_ context.Context, method string) {
+		if method == "textDocument/hover" {
+			panic("boom")
+		}
+	}
+
+	code, replies := runSessionWithHook(t, hook,
+		request(1, "initialize", message{"capabilities": message{}}),
+		request(2, "textDocument/hover", message{
+			"textDocument": message{"uri": "file:///foo/terragrunt.hcl"},
+			"position":     message{"line": 0, "character": 0},
+		}),
+		request(3, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+	require.Len(t, replies, 3)
+
+	assert.InDelta(t, -32603, errorCode(t, findReply(t, replies, 2)), 0)
+	assert.Contains(t, findReply(t, replies, 3), "result")
+}
+
+func TestServe_LargeDocument(t *testing.T) {
+	t.Parallel()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+
+	// Generated configs can be larger than the 64KB a bufio.Scanner accepts.
+	var text strings.Builder
+
+	text.WriteString("locals {\n\tfoo = \"bar\"\n")
+
+	for i := range 2000 {
+		fmt.Fprintf(&text, "\tgenerated_%d = \"%s\"\n", i, strings.Repeat("x", 32))
+	}
+
+	text.WriteString("}\n")
+
+	code, replies := runSession(t,
+		request(1, "initialize", message{"capabilities": message{}}),
+		notification("textDocument/didOpen", message{
+			"textDocument": message{"uri": docURI, "languageId": "terragrunt", "version": 1, "text": text.String()},
+		}),
+		request(2, "textDocument/hover", message{
+			"textDocument": message{"uri": docURI},
+			"position":     message{"line": 1, "character": 2},
+		}),
+		request(3, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Equal(t, server.ExitCodeSuccess, code)
+	require.Len(t, replies, 4)
+	assert.Contains(t, findReply(t, replies, 2), "result")
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"go.lsp.dev/protocol"
+)
+
+// watchersID is the ID the file watchers are registered with.
+const watchersID = "terragrunt-ls/watchers"
+
+// watchedFiles are the glob patterns of the files that parsed documents
+// depend on: the Terragrunt files they include or depend on, and the
+// Terraform files of their modules.
+var watchedFiles = []string{"**/*.hcl", "**/*.tf"}
+
+// registerWatchers asks the client to notify the server of changes to the
+// watched files, when it supports registering watchers dynamically.
+func (s *Server) registerWatchers(ctx context.Context) {
+	if workspace := s.clientCapabilities.Workspace; workspace == nil || workspace.DidChangeWatchedFiles == nil || !workspace.DidChangeWatchedFiles.DynamicRegistration {
+		s.l.Debug("Client does not support registering file watchers")
+
+		return
+	}
+
+	watchers := make([]protocol.FileSystemWatcher, 0, len(watchedFiles))
+	for _, pattern := range watchedFiles {
+		watchers = append(watchers, protocol.FileSystemWatcher{GlobPattern: pattern})
+	}
+
+	err := s.registerCapability(ctx, protocol.Registration{
+		ID:     watchersID,
+		Method: protocol.MethodWorkspaceDidChangeWatchedFiles,
+		RegisterOptions: protocol.DidChangeWatchedFilesRegistrationOptions{
+			Watchers: watchers,
+		},
+	})
+	if err != nil {
+		s.l.Warn("Failed to register file watchers", "error", err)
+	}
+}
+
+// changeWatchedFiles re-indexes the files changed on disk, reloads the
+// closed documents still held from disk, and re-parses the open documents
+// that include or depend on them, directly or through other units.
+//
+// A changed file is re-indexed in the index of the workspace folder it
+// belongs to, and in any other index that already holds it or a file that
+// includes it, as folders index the files they include from outside.
+//
+// Affected documents are validated against the filesystem too, as that is
+// what changed.
+func (s *Server) changeWatchedFiles(ctx context.Context, changes []*protocol.FileEvent) {
+	indexes := s.state.Indexes()
+	changed := make(map[string]bool, len(changes))
+
+	for _, change := range changes {
+		if change == nil {
+			continue
+		}
+
+		path := change.URI.Filename()
+		changed[path] = true
+
+		s.state.RefreshDiskDocument(s.l, change.URI)
+
+		s.l.Debug(
+			"Watched file changed",
+			"path", path,
+			"type", change.Type.String(),
+		)
+
+		if filepath.Ext(path) != ".hcl" {
+			continue
+		}
+
+		owner := s.state.Index(path)
+
+		for _, index := range indexes {
+			if change.Type == protocol.FileChangeTypeDeleted {
+				index.Remove(path)
+
+				continue
+			}
+
+			if !shouldIndex(index, path, index == owner) {
+				continue
+			}
+
+			if _, err := index.IndexFile(s.l, path); err != nil {
+				s.l.Debug("Failed to re-index changed file", "path", path, "error", err)
+			}
+		}
+	}
+
+	s.docsMu.Lock()
+	defer s.docsMu.Unlock()
+
+	for docURI, d := range s.docs {
+		file, ok := s.state.WorkspaceFile(docURI)
+		if !ok || !affectedBy(file, changed) && !reaches(s.state.Index(file.Path), file, changed) {
+			continue
+		}
+
+		s.l.Debug("Re-parsing document affected by changed files", "URI", docURI)
+
+		s.scheduleDiagnostics(ctx, docURI, d, 0, true)
+	}
+}
+
+// shouldIndex reports whether a changed HCL file belongs in the index: it
+// is a Terragrunt configuration file of the folder that owns the index, or a
+// file that is already indexed or included by an indexed file.
+func shouldIndex(index *workspace.Index, path string, owned bool) bool {
+	if owned && workspace.IsConfigFile(path) {
+		return true
+	}
+
+	if _, ok := index.File(path); ok {
+		return true
+	}
+
+	return len(index.Includers(path)) > 0
+}
+
+// reaches reports whether any of the changed files is the configuration of a
+// unit that file depends on through other units, as changes to those may
+// close or break a dependency cycle.
+func reaches(index *workspace.Index, file *workspace.File, changed map[string]bool) bool {
+	for path := range index.Reachable(file) {
+		if changed[path] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// affectedBy reports whether file includes or depends on any of the changed
+// files, or has its module among them.
+func affectedBy(file *workspace.File, changed map[string]bool) bool {
+	for _, include := range file.Includes {
+		if changed[include.Path] {
+			return true
+		}
+	}
+
+	for _, dep := range file.Dependencies {
+		if changed[dep.Path] {
+			return true
+		}
+	}
+
+	if file.Source == "" {
+		return false
+	}
+
+	for path := range changed {
+		if filepath.Ext(path) == ".tf" && filepath.Dir(path) == file.Source {
+			return true
+		}
+	}
+
+	return false
+}
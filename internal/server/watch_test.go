@@ -0,0 +1,250 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/server"
+	"terragrunt-ls/internal/testutils"
+)
+
+func TestWatch_RegistersWatchers(t *testing.T) {
+	t.Parallel()
+
+	c := startClient(t, message{
+		"workspace": message{"didChangeWatchedFiles": message{"dynamicRegistration": true}},
+	})
+
+	c.send(notification("initialized", message{}))
+
+	register := c.read()
+	require.Equal(t, "client/registerCapability", register["method"])
+	assert.Equal(t, message{
+		"registrations": []any{
+			map[string]any{
+				"id":     "terragrunt-ls/watchers",
+				"method": "workspace/didChangeWatchedFiles",
+				"registerOptions": map[string]any{
+					"watchers": []any{
+						map[string]any{"globPattern": "**/*.hcl"},
+						map[string]any{"globPattern": "**/*.tf"},
+					},
+				},
+			},
+		},
+	}, params(register))
+
+	c.send(message{"jsonrpc": "2.0", "id": register["id"], "result": nil})
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
+
+// changeWatchedFile notifies the server that a file changed on disk.
+func (c *testClient) changeWatchedFile(path string, changeType int) {
+	c.t.Helper()
+
+	c.send(notification("workspace/didChangeWatchedFiles", message{
+		"changes": []message{{"uri": uri.File(path), "type": changeType}},
+	}))
+}
+
+// sync waits for the server to handle every notification sent so far. As
+// notifications are handled in order, once a request sent after them is
+// answered, they have all been applied.
+func (c *testClient) sync() {
+	c.t.Helper()
+
+	c.send(request("sync", "textDocument/hover", message{
+		"textDocument": message{"uri": "file:///sync.hcl"},
+		"position":     message{"line": 0, "character": 0},
+	}))
+
+	assert.Equal(c.t, "sync", c.read()["id"])
+}
+
+func TestWatch_ReparsesAffectedDocuments(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"app", "other", "db", "modules/app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0755))
+	}
+
+	rootPath, err := testutils.CreateFile(tmpDir, "root.hcl", "")
+	require.NoError(t, err)
+
+	appText := `include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "../modules/app"
+}
+
+dependency "db" {
+  config_path = "../db"
+
+  mock_outputs = {
+    id = "mock"
+  }
+}
+`
+
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+	otherURI := uri.File(filepath.Join(tmpDir, "other", "terragrunt.hcl"))
+
+	c := startClient(t, message{})
+
+	c.openDocument(appURI, appText)
+	c.openDocument(otherURI, "")
+
+	// Changes to included files re-parse and validate the documents that
+	// include them.
+	c.changeWatchedFile(rootPath, 2)
+
+	published := c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.Equal(t, string(appURI), params(published)["uri"])
+
+	diagnostics := params(published)["diagnostics"].([]any)
+	require.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].(map[string]any)["message"], "Dependency not found")
+
+	// Creating the configuration of a dependency clears the diagnostic.
+	dbPath, err := testutils.CreateFile(filepath.Join(tmpDir, "db"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	c.changeWatchedFile(dbPath, 1)
+
+	published = c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.Equal(t, string(appURI), params(published)["uri"])
+	assert.Empty(t, params(published)["diagnostics"])
+
+	// Changes to the module of a unit re-parse it too.
+	modulePath, err := testutils.CreateFile(filepath.Join(tmpDir, "modules", "app"), "main.tf", "")
+	require.NoError(t, err)
+
+	c.changeWatchedFile(modulePath, 1)
+
+	published = c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.Equal(t, string(appURI), params(published)["uri"])
+
+	// Changes to unrelated files re-parse nothing.
+	unrelatedPath, err := testutils.CreateFile(tmpDir, "unrelated.hcl", "")
+	require.NoError(t, err)
+
+	c.changeWatchedFile(unrelatedPath, 1)
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
+
+func TestWatch_ReindexesChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "app"), 0755))
+
+	appPath, err := testutils.CreateFile(filepath.Join(tmpDir, "app"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	c := startClient(t, message{})
+	index := c.server.Index(tmpDir)
+
+	c.changeWatchedFile(appPath, 1)
+
+	// Non-Terragrunt files are only indexed once a Terragrunt file includes them.
+	commonPath, err := testutils.CreateFile(tmpDir, "common.hcl", "")
+	require.NoError(t, err)
+
+	c.changeWatchedFile(commonPath, 1)
+	c.sync()
+
+	_, ok := index.File(commonPath)
+	assert.False(t, ok)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "app"), "terragrunt.hcl", "include \"common\" {\n  path = \"../common.hcl\"\n}\n")
+	require.NoError(t, err)
+
+	c.changeWatchedFile(appPath, 2)
+	c.changeWatchedFile(commonPath, 2)
+
+	require.NoError(t, os.Remove(appPath))
+	c.changeWatchedFile(appPath, 3)
+
+	c.sync()
+
+	_, ok = index.File(appPath)
+	assert.False(t, ok)
+
+	common, ok := index.File(commonPath)
+	require.True(t, ok)
+	assert.Equal(t, commonPath, common.Path)
+}
+
+func TestWatch_ReportsDependencyCycles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"app", "db", "vpc"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0755))
+	}
+
+	appText := "dependency \"db\" {\n  config_path = \"../db\"\n}\n"
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "app"), "terragrunt.hcl", appText)
+	require.NoError(t, err)
+
+	dbPath, err := testutils.CreateFile(filepath.Join(tmpDir, "db"), "terragrunt.hcl", "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n")
+	require.NoError(t, err)
+
+	vpcPath, err := testutils.CreateFile(filepath.Join(tmpDir, "vpc"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+
+	c := startClient(t, message{})
+
+	for _, path := range []string{appURI.Filename(), dbPath, vpcPath} {
+		c.changeWatchedFile(path, 1)
+	}
+
+	c.openDocument(appURI, appText)
+
+	// Closing the cycle two units away re-parses the unit.
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "vpc"), "terragrunt.hcl", "dependency \"app\" {\n  config_path = \"../app\"\n}\n")
+	require.NoError(t, err)
+
+	c.changeWatchedFile(vpcPath, 2)
+
+	published := c.read()
+	require.Equal(t, "textDocument/publishDiagnostics", published["method"])
+	assert.Equal(t, string(appURI), params(published)["uri"])
+
+	diagnostics := params(published)["diagnostics"].([]any)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "Dependency cycle: app -> db -> vpc -> app", diagnostics[0].(map[string]any)["message"])
+	assert.Len(t, diagnostics[0].(map[string]any)["relatedInformation"], 2)
+
+	c.send(request("bye", "shutdown", nil))
+	assert.Equal(t, "bye", c.read()["id"])
+
+	c.send(notification("exit", nil))
+	assert.Equal(t, server.ExitCodeSuccess, <-c.exitCode)
+}
@@ -4,6 +4,7 @@ package completion
 import (
 	"strings"
 	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/schema"
 	"terragrunt-ls/internal/tg/store"
 	"terragrunt-ls/internal/tg/text"
 
@@ -44,13 +45,8 @@ func GetCompletions(l logger.Logger, s store.Store, position protocol.Position)
 func newUnitCompletions(position protocol.Position) []protocol.CompletionItem {
 	return []protocol.CompletionItem{
 		{
-			Label: "dependency",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# dependency
-The dependency block is used to configure unit dependencies.
-Each dependency block exposes outputs of the dependency unit as variables you can reference in dependent unit configuration.`,
-			},
+			Label:            "dependency",
+			Documentation:    documentation(schema.Unit, "dependency"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -64,12 +60,8 @@ Each dependency block exposes outputs of the dependency unit as variables you ca
 			},
 		},
 		{
-			Label: "inputs",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# inputs
-The inputs attribute is a map that is used to specify the input variables and their values to pass in to OpenTofu/Terraform.`,
-			},
+			Label:            "inputs",
+			Documentation:    documentation(schema.Unit, "inputs"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -83,12 +75,8 @@ The inputs attribute is a map that is used to specify the input variables and th
 			},
 		},
 		{
-			Label: "locals",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# locals
-The locals block is used to define aliases for Terragrunt expressions that can be referenced elsewhere in configuration.`,
-			},
+			Label:            "locals",
+			Documentation:    documentation(schema.Unit, "locals"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -102,12 +90,8 @@ The locals block is used to define aliases for Terragrunt expressions that can b
 			},
 		},
 		{
-			Label: "feature",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# feature
-The feature block is used to configure feature flags in HCL for a specific Terragrunt unit.`,
-			},
+			Label:            "feature",
+			Documentation:    documentation(schema.Unit, "feature"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -121,12 +105,8 @@ The feature block is used to configure feature flags in HCL for a specific Terra
 			},
 		},
 		{
-			Label: "terraform",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# terraform
-The terraform block is used to configure how Terragrunt will interact with OpenTofu/Terraform.`,
-			},
+			Label:            "terraform",
+			Documentation:    documentation(schema.Unit, "terraform"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -140,12 +120,8 @@ The terraform block is used to configure how Terragrunt will interact with OpenT
 			},
 		},
 		{
-			Label: "remote_state",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# remote_state
-The remote_state block is used to configure how Terragrunt will set up remote state configuration.`,
-			},
+			Label:            "remote_state",
+			Documentation:    documentation(schema.Unit, "remote_state"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -164,12 +140,8 @@ The remote_state block is used to configure how Terragrunt will set up remote st
 			},
 		},
 		{
-			Label: "include",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# include
-The include block is used to specify the inclusion of partial Terragrunt configuration.`,
-			},
+			Label:            "include",
+			Documentation:    documentation(schema.Unit, "include"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -183,12 +155,8 @@ The include block is used to specify the inclusion of partial Terragrunt configu
 			},
 		},
 		{
-			Label: "dependencies",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# dependencies
-The dependencies block is used to enumerate all the Terragrunt units that need to be applied before this unit.`,
-			},
+			Label:            "dependencies",
+			Documentation:    documentation(schema.Unit, "dependencies"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -202,12 +170,8 @@ The dependencies block is used to enumerate all the Terragrunt units that need t
 			},
 		},
 		{
-			Label: "generate",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# generate
-The generate block can be used to arbitrarily generate a file in the terragrunt working directory.`,
-			},
+			Label:            "generate",
+			Documentation:    documentation(schema.Unit, "generate"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -227,12 +191,8 @@ EOF
 			},
 		},
 		{
-			Label: "engine",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# engine
-The engine block is used to configure Terragrunt engine configuration.`,
-			},
+			Label:            "engine",
+			Documentation:    documentation(schema.Unit, "engine"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -247,12 +207,8 @@ The engine block is used to configure Terragrunt engine configuration.`,
 			},
 		},
 		{
-			Label: "exclude",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# exclude
-The exclude block provides configuration options to dynamically determine when and how a unit is excluded from the run queue.`,
-			},
+			Label:            "exclude",
+			Documentation:    documentation(schema.Unit, "exclude"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -267,12 +223,8 @@ The exclude block provides configuration options to dynamically determine when a
 			},
 		},
 		{
-			Label: "download_dir",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# download_dir
-The download_dir string option can be used to override the default download directory (which is .terragrunt-cache by default).`,
-			},
+			Label:            "download_dir",
+			Documentation:    documentation(schema.Unit, "download_dir"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -284,12 +236,8 @@ The download_dir string option can be used to override the default download dire
 			},
 		},
 		{
-			Label: "prevent_destroy",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# prevent_destroy
-The prevent_destroy boolean attribute prevents the unit from being destroyed.`,
-			},
+			Label:            "prevent_destroy",
+			Documentation:    documentation(schema.Unit, "prevent_destroy"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -301,12 +249,8 @@ The prevent_destroy boolean attribute prevents the unit from being destroyed.`,
 			},
 		},
 		{
-			Label: "iam_role",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# iam_role
-The iam_role attribute is used to specify an IAM role that Terragrunt should assume prior to running OpenTofu/Terraform.`,
-			},
+			Label:            "iam_role",
+			Documentation:    documentation(schema.Unit, "iam_role"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -318,12 +262,8 @@ The iam_role attribute is used to specify an IAM role that Terragrunt should ass
 			},
 		},
 		{
-			Label: "iam_assume_role_duration",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# iam_assume_role_duration
-The iam_assume_role_duration attribute is used to specify the STS session duration, in seconds.`,
-			},
+			Label:            "iam_assume_role_duration",
+			Documentation:    documentation(schema.Unit, "iam_assume_role_duration"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -335,12 +275,8 @@ The iam_assume_role_duration attribute is used to specify the STS session durati
 			},
 		},
 		{
-			Label: "iam_assume_role_session_name",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# iam_assume_role_session_name
-The iam_assume_role_session_name attribute is used to specify the STS session name.`,
-			},
+			Label:            "iam_assume_role_session_name",
+			Documentation:    documentation(schema.Unit, "iam_assume_role_session_name"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -352,12 +288,8 @@ The iam_assume_role_session_name attribute is used to specify the STS session na
 			},
 		},
 		{
-			Label: "iam_web_identity_token",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# iam_web_identity_token
-The iam_web_identity_token attribute is used along with iam_role to assume a role using the AssumeRoleWithWebIdentity API.`,
-			},
+			Label:            "iam_web_identity_token",
+			Documentation:    documentation(schema.Unit, "iam_web_identity_token"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -369,12 +301,8 @@ The iam_web_identity_token attribute is used along with iam_role to assume a rol
 			},
 		},
 		{
-			Label: "terraform_binary",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# terraform_binary
-The terraform_binary attribute is used to override the binary Terragrunt uses during runs (which is tofu by default).`,
-			},
+			Label:            "terraform_binary",
+			Documentation:    documentation(schema.Unit, "terraform_binary"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -386,12 +314,8 @@ The terraform_binary attribute is used to override the binary Terragrunt uses du
 			},
 		},
 		{
-			Label: "terraform_version_constraint",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# terraform_version_constraint
-The terraform_version_constraint attribute is used to override the default minimum supported version of OpenTofu/Terraform.`,
-			},
+			Label:            "terraform_version_constraint",
+			Documentation:    documentation(schema.Unit, "terraform_version_constraint"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -403,12 +327,8 @@ The terraform_version_constraint attribute is used to override the default minim
 			},
 		},
 		{
-			Label: "terragrunt_version_constraint",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# terragrunt_version_constraint
-The terragrunt_version_constraint attribute is used to specify which versions of the Terragrunt CLI can be used.`,
-			},
+			Label:            "terragrunt_version_constraint",
+			Documentation:    documentation(schema.Unit, "terragrunt_version_constraint"),
 			Kind:             protocol.CompletionItemKindField,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -426,12 +346,8 @@ The terragrunt_version_constraint attribute is used to specify which versions of
 func newStackCompletions(position protocol.Position) []protocol.CompletionItem {
 	return []protocol.CompletionItem{
 		{
-			Label: "unit",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# unit
-The unit block references a Terragrunt unit to include in this stack.`,
-			},
+			Label:            "unit",
+			Documentation:    documentation(schema.Stack, "unit"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -446,12 +362,8 @@ The unit block references a Terragrunt unit to include in this stack.`,
 			},
 		},
 		{
-			Label: "stack",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# stack
-The stack block references another Terragrunt stack to nest within this stack.`,
-			},
+			Label:            "stack",
+			Documentation:    documentation(schema.Stack, "stack"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -466,12 +378,8 @@ The stack block references another Terragrunt stack to nest within this stack.`,
 			},
 		},
 		{
-			Label: "locals",
-			Documentation: protocol.MarkupContent{
-				Kind: protocol.Markdown,
-				Value: `# locals
-The locals block defines aliases for expressions reusable within the stack file.`,
-			},
+			Label:            "locals",
+			Documentation:    documentation(schema.Stack, "locals"),
 			Kind:             protocol.CompletionItemKindClass,
 			InsertTextFormat: protocol.InsertTextFormatSnippet,
 			TextEdit: &protocol.TextEdit{
@@ -486,3 +394,13 @@ The locals block defines aliases for expressions reusable within the stack file.
 		},
 	}
 }
+
+// documentation returns the documentation of the element called name of root.
+func documentation(root schema.Element, name string) protocol.MarkupContent {
+	element, _ := root.Find(name)
+
+	return protocol.MarkupContent{
+		Kind:  protocol.Markdown,
+		Value: element.Documentation(),
+	}
+}
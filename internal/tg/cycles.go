@@ -0,0 +1,74 @@
+package tg
+
+import (
+	"path/filepath"
+	"strings"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// DependencyCycles returns an error diagnostic on each dependency path of a
+// unit that leads back to the unit, with the other links of the cycle as
+// related information.
+//
+// The other units are taken from the index of the workspace folder of the
+// unit, so cycles through units that are not indexed yet are not found.
+func (s *State) DependencyCycles(l logger.Logger, docURI protocol.DocumentURI, st store.Store) []protocol.Diagnostic {
+	diags := []protocol.Diagnostic{}
+
+	if st.FileType != store.FileTypeUnit || st.AST == nil {
+		return diags
+	}
+
+	filename := docURI.Filename()
+
+	for _, cycle := range s.Index(filename).Cycles(workspace.NewFile(filename, st.AST)) {
+		names := make([]string, 0, len(cycle)+1)
+		names = append(names, unitName(filename))
+
+		related := make([]protocol.DiagnosticRelatedInformation, 0, len(cycle)-1)
+
+		for i, link := range cycle {
+			names = append(names, unitName(link.Dependency.Path))
+
+			if i == 0 {
+				continue
+			}
+
+			related = append(related, protocol.DiagnosticRelatedInformation{
+				Location: protocol.Location{
+					URI:   uri.File(link.Path),
+					Range: link.Dependency.Range,
+				},
+				Message: unitName(link.Path) + " depends on " + unitName(link.Dependency.Path),
+			})
+		}
+
+		message := "Dependency cycle: " + strings.Join(names, " -> ")
+
+		l.Debug(
+			"Found dependency cycle",
+			"uri", docURI,
+			"cycle", message,
+		)
+
+		diag := newValidationDiagnostic(cycle[0].Dependency.Range, protocol.DiagnosticSeverityError, message)
+		if len(related) > 0 {
+			diag.RelatedInformation = related
+		}
+
+		diags = append(diags, diag)
+	}
+
+	return diags
+}
+
+// unitName returns the name of the unit whose configuration is at path,
+// which is the name of its directory.
+func unitName(path string) string {
+	return filepath.Base(filepath.Dir(path))
+}
@@ -3,6 +3,7 @@
 package definition
 
 import (
+	"strings"
 	"terragrunt-ls/internal/ast"
 	"terragrunt-ls/internal/logger"
 	"terragrunt-ls/internal/tg/store"
@@ -27,6 +28,13 @@ const (
 	// This means that the user is trying to find the definition of a dependency.
 	DefinitionContextDependency = "dependency"
 
+	// DefinitionContextIncludeAttribute is the context for the definition of
+	// a local or input of an included file.
+	// This means that the user is trying to find the definition of an
+	// `include.<label>.locals.<name>` or `include.<label>.inputs.<name>`
+	// reference, and the target is `<label>.<attr>.<name>`.
+	DefinitionContextIncludeAttribute = "include_attribute"
+
 	// DefinitionContextNull is the context for a null definition.
 	// This means that the user is trying to go to the definition of nothing useful.
 	DefinitionContextNull = "null"
@@ -67,8 +75,12 @@ func GetDefinitionTargetWithContext(l logger.Logger, store store.Store, position
 }
 
 // traversalDefinitionTarget extracts a (name, context) pair from a
-// `local.<name>` traversal.
+// `local.<name>` or `include.<label>.<attr>.<name>` traversal.
 func traversalDefinitionTarget(expr *hclsyntax.ScopeTraversalExpr) (string, string, bool) {
+	if ref, ok := ast.GetIncludeReference(expr); ok {
+		return strings.Join([]string{ref.Label, ref.Attr, ref.Name}, "."), DefinitionContextIncludeAttribute, true
+	}
+
 	if len(expr.Traversal) < ast.MinReferenceTraversalLen {
 		return "", "", false
 	}
@@ -46,6 +46,15 @@ func TestGetDefinitionTargetWithContext(t *testing.T) {
 			expectedTarget:  "vpc",
 			expectedContext: "dependency",
 		},
+		{
+			name: "include attribute definition",
+			document: `inputs = {
+	region = include.root.locals.region
+}`,
+			position:        protocol.Position{Line: 1, Character: 32},
+			expectedTarget:  "root.locals.region",
+			expectedContext: "include_attribute",
+		},
 	}
 
 	for _, tt := range tc {
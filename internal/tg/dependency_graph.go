@@ -0,0 +1,25 @@
+package tg
+
+import (
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/tg/graph"
+)
+
+// DependencyGraph returns the dependency graph of the indexed units under
+// root, in format.
+func (s *State) DependencyGraph(l logger.Logger, id lsp.ID, root string, format graph.Format) lsp.ExecuteCommandResponse {
+	g := graph.Build(root, s.Index(root).Files())
+
+	l.Debug(
+		"Built dependency graph",
+		"root", root,
+		"format", format,
+		"units", len(g.Units),
+	)
+
+	return lsp.ExecuteCommandResponse{
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
+		Result:   g.Render(format),
+	}
+}
@@ -0,0 +1,190 @@
+package tg
+
+import (
+	"path/filepath"
+	"strings"
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/module"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/text"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// dependencyOutputHover returns the hover of a `<name>.<output>` target of
+// the document: the unit the dependency resolves to, the mock of the output
+// and the commands it is allowed for, and the declaration of the output when
+// the module of the unit is local.
+func (s *State) dependencyOutputHover(l logger.Logger, st store.Store, docURI protocol.DocumentURI, target string) (string, bool) {
+	name, output, ok := strings.Cut(target, ".")
+	if !ok || st.AST == nil || st.AST.HCLFile == nil {
+		return "", false
+	}
+
+	block, ok := dependencyBlock(st.AST, name)
+	if !ok {
+		l.Debug(
+			"Dependency not found",
+			"uri", docURI,
+			"dependency", name,
+		)
+
+		return "", false
+	}
+
+	var sections []string
+
+	path, resolved := dependencyPath(st, docURI.Filename(), name)
+	if resolved {
+		sections = append(sections, "`config_path`: `"+filepath.Dir(path)+"`")
+	}
+
+	if mock, ok := mockOutput(block, output, st.AST.HCLFile.Bytes); ok {
+		sections = append(sections, "Mock output, used for "+allowedCommands(block, st.AST.HCLFile.Bytes)+":", text.WrapAsHCLCodeFence(mock))
+	}
+
+	if resolved {
+		if source := s.unitModuleDir(path); source != "" {
+			if decl, ok := module.FindOutput(source, output); ok {
+				sections = append(sections, "Output declared in `"+relativePath(docURI, decl.Path)+"`:")
+
+				if decl.Description != "" {
+					sections = append(sections, decl.Description)
+				}
+
+				sections = append(sections, text.WrapAsHCLCodeFence(strings.TrimSpace(decl.Source)))
+			}
+		}
+	}
+
+	if len(sections) == 0 {
+		return "", false
+	}
+
+	return strings.Join(sections, "\n\n"), true
+}
+
+// dependencyBlock returns the `dependency` block called name.
+func dependencyBlock(indexedAST *ast.IndexedAST, name string) (*hclsyntax.Block, bool) {
+	body, ok := indexedAST.HCLFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type == "dependency" && len(block.Labels) > 0 && block.Labels[0] == name {
+			return block, true
+		}
+	}
+
+	return nil, false
+}
+
+// dependencyPath returns the path of the configuration of the dependency
+// called name of the unit at filename, if it could be resolved.
+//
+// The `config_path` resolved by Terragrunt is preferred, as it follows
+// locals and functions such as `get_repo_root()`. The AST is only used when
+// Terragrunt could not resolve it.
+func dependencyPath(st store.Store, filename, name string) (string, bool) {
+	if st.Cfg != nil {
+		for _, dep := range st.Cfg.TerragruntDependencies {
+			if dep.Name != name || dep.ConfigPath.IsNull() || !dep.ConfigPath.IsKnown() || dep.ConfigPath.Type() != cty.String {
+				continue
+			}
+
+			return workspace.ResolveConfigPath(filepath.Dir(filename), dep.ConfigPath.AsString()), true
+		}
+	}
+
+	for _, dep := range workspace.NewFile(filename, st.AST).Dependencies {
+		if dep.Name == name {
+			return dep.Path, true
+		}
+	}
+
+	return "", false
+}
+
+// unitModuleDir returns the directory of the local module of the unit whose
+// configuration is at path, or "" when it has none.
+//
+// Only an open document is evaluated by Terragrunt. Other units are read
+// from the index, or from disk, without evaluating their functions, so that
+// hovering never runs the functions of a file the user did not open.
+func (s *State) unitModuleDir(path string) string {
+	if st, ok := s.lookupOpen(uri.File(path)); ok {
+		return moduleDir(st, path)
+	}
+
+	index := s.Index(path)
+
+	if file, ok := index.File(path); ok {
+		return file.Source
+	}
+
+	unitAST, _ := s.loadAST(index, path)
+
+	return workspace.NewFile(path, unitAST).Source
+}
+
+// mockOutput returns the source of the item of the `mock_outputs` of block
+// for output.
+func mockOutput(block *hclsyntax.Block, output string, src []byte) (string, bool) {
+	attr, ok := block.Body.Attributes["mock_outputs"]
+	if !ok {
+		return "", false
+	}
+
+	object, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return "", false
+	}
+
+	for _, item := range object.Items {
+		if key, ok := ast.ObjectKeyName(item.KeyExpr); ok && key == output {
+			return string(hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()).SliceBytes(src)), true
+		}
+	}
+
+	return "", false
+}
+
+// allowedCommands describes the commands the mock outputs of block are used
+// for, which are all of them unless `mock_outputs_allowed_terraform_commands`
+// is set. Commands that are not a list of string literals are quoted from
+// src.
+func allowedCommands(block *hclsyntax.Block, src []byte) string {
+	attr, ok := block.Body.Attributes["mock_outputs_allowed_terraform_commands"]
+	if !ok {
+		return "every command"
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+		return "`" + string(attr.Expr.Range().SliceBytes(src)) + "`"
+	}
+
+	var commands []string
+
+	for it := value.ElementIterator(); it.Next(); {
+		_, command := it.Element()
+		if command.IsNull() || command.Type() != cty.String {
+			continue
+		}
+
+		commands = append(commands, "`"+command.AsString()+"`")
+	}
+
+	if len(commands) == 0 {
+		return "no command"
+	}
+
+	return strings.Join(commands, ", ")
+}
@@ -0,0 +1,32 @@
+package tg
+
+import (
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/tg/symbols"
+
+	"go.lsp.dev/protocol"
+)
+
+// TextDocumentSymbols returns the outline of the last parsed version of the
+// document.
+func (s *State) TextDocumentSymbols(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI) lsp.DocumentSymbolResponse {
+	result := []protocol.DocumentSymbol{}
+
+	if st, ok := s.lookup(docURI); ok {
+		if outline := symbols.Outline(st.FileType, st.AST); outline != nil {
+			result = outline
+		}
+	}
+
+	l.Debug(
+		"Built document outline",
+		"uri", docURI,
+		"symbols", len(result),
+	)
+
+	return lsp.DocumentSymbolResponse{
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
+		Result:   result,
+	}
+}
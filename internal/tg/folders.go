@@ -0,0 +1,107 @@
+package tg
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// AddFolder adds a workspace folder, and returns the index of its files. The
+// existing index is returned when the folder was already added.
+func (s *State) AddFolder(root string) *workspace.Index {
+	root = filepath.Clean(root)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index, ok := s.folders[root]; ok {
+		return index
+	}
+
+	index := workspace.NewIndex()
+	s.folders[root] = index
+
+	return index
+}
+
+// Folder returns the index of the files of a workspace folder, and whether
+// the folder was added.
+func (s *State) Folder(root string) (*workspace.Index, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	index, ok := s.folders[filepath.Clean(root)]
+
+	return index, ok
+}
+
+// RemoveFolder removes a workspace folder, along with the index of its files.
+func (s *State) RemoveFolder(root string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.folders, filepath.Clean(root))
+}
+
+// Folders returns the roots of the workspace folders, sorted.
+func (s *State) Folders() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(s.folders))
+}
+
+// Index returns the index of the workspace folder the file at path belongs
+// to, which is the innermost folder that contains it. Files outside every
+// folder share an index of their own.
+//
+// Cross-file features only look at the index of the folder of the file
+// they are requested on, so that folders do not see each other's files.
+func (s *State) Index(path string) *workspace.Index {
+	_, index := s.folder(path)
+
+	return index
+}
+
+// folder returns the root and the index of the workspace folder the file at
+// path belongs to. The root is empty for files outside every folder.
+func (s *State) folder(path string) (string, *workspace.Index) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	owner, index := "", s.index
+
+	for root, folderIndex := range s.folders {
+		if len(root) > len(owner) && contains(root, path) {
+			owner, index = root, folderIndex
+		}
+	}
+
+	return owner, index
+}
+
+// Indexes returns the index of every workspace folder, and the index of the
+// files outside every folder.
+func (s *State) Indexes() []*workspace.Index {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	indexes := make([]*workspace.Index, 0, len(s.folders)+1)
+	for _, root := range slices.Sorted(maps.Keys(s.folders)) {
+		indexes = append(indexes, s.folders[root])
+	}
+
+	return append(indexes, s.index)
+}
+
+// contains reports whether path is root or is inside it.
+func contains(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
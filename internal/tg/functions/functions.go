@@ -0,0 +1,83 @@
+// Package functions provides the catalog of the functions that can be called
+// in Terragrunt configuration: the built-ins of Terragrunt, and the
+// OpenTofu/Terraform functions it makes available alongside them.
+package functions
+
+import (
+	"slices"
+	"strings"
+	"terragrunt-ls/internal/tg/text"
+)
+
+// Function is the documentation of a function.
+type Function struct {
+	// Name is the name the function is called by.
+	Name string
+
+	// Parameters are the labels of the parameters of the function, such as
+	// `path string`. Optional parameters are suffixed with `?`, and variadic
+	// ones are prefixed with `...`.
+	Parameters []string
+
+	// Returns is the type of the value the function returns.
+	Returns string
+
+	// Description is what the function does, in Markdown.
+	Description string
+
+	// Example is an HCL example of a call to the function.
+	Example string
+
+	// Terragrunt is true for the built-ins of Terragrunt, and false for the
+	// functions of OpenTofu/Terraform.
+	Terragrunt bool
+}
+
+// Signature returns the signature of f, such as
+// `find_in_parent_folders(name? string, fallback? string) string`.
+func (f Function) Signature() string {
+	return f.Name + "(" + strings.Join(f.Parameters, ", ") + ") " + f.Returns
+}
+
+// Documentation returns the Markdown documentation of f: its signature, its
+// description and its example.
+func (f Function) Documentation() string {
+	return text.WrapAsHCLCodeFence(f.Signature()) + "\n\n" + f.Description + "\n\nExample:\n\n" + text.WrapAsHCLCodeFence(f.Example)
+}
+
+// catalog is every function, keyed by name.
+var catalog = func() map[string]Function {
+	m := make(map[string]Function, len(terragruntFunctions)+len(stdlibFunctions))
+
+	for _, f := range terragruntFunctions {
+		f.Terragrunt = true
+		m[f.Name] = f
+	}
+
+	for _, f := range stdlibFunctions {
+		m[f.Name] = f
+	}
+
+	return m
+}()
+
+// Lookup returns the function called name.
+func Lookup(name string) (Function, bool) {
+	f, ok := catalog[name]
+
+	return f, ok
+}
+
+// All returns every function, sorted by name.
+func All() []Function {
+	all := make([]Function, 0, len(catalog))
+	for _, f := range catalog {
+		all = append(all, f)
+	}
+
+	slices.SortFunc(all, func(a, b Function) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return all
+}
@@ -0,0 +1,89 @@
+package functions_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/tg/functions"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name       string
+		function   string
+		signature  string
+		terragrunt bool
+		found      bool
+	}{
+		{
+			name:       "terragrunt function",
+			function:   "find_in_parent_folders",
+			signature:  "find_in_parent_folders(name? string, fallback? string) string",
+			terragrunt: true,
+			found:      true,
+		},
+		{
+			name:       "variadic terragrunt function",
+			function:   "run_cmd",
+			signature:  "run_cmd(...args string) string",
+			terragrunt: true,
+			found:      true,
+		},
+		{
+			name:      "stdlib function",
+			function:  "merge",
+			signature: "merge(...maps map(any)) map(any)",
+			found:     true,
+		},
+		{
+			name:       "stdlib function overridden by terragrunt",
+			function:   "startswith",
+			signature:  "startswith(str string, prefix string) bool",
+			terragrunt: true,
+			found:      true,
+		},
+		{
+			name:     "unknown function",
+			function: "my_function",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fn, found := functions.Lookup(tt.function)
+
+			require.Equal(t, tt.found, found)
+
+			if !found {
+				return
+			}
+
+			assert.Equal(t, tt.signature, fn.Signature())
+			assert.Equal(t, tt.terragrunt, fn.Terragrunt)
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	all := functions.All()
+
+	assert.True(t, slices.IsSortedFunc(all, func(a, b functions.Function) int {
+		return strings.Compare(a.Name, b.Name)
+	}))
+
+	for _, fn := range all {
+		assert.NotEmpty(t, fn.Returns, fn.Name)
+		assert.NotEmpty(t, fn.Description, fn.Name)
+		assert.Contains(t, fn.Example, fn.Name+"(", fn.Name)
+	}
+}
@@ -0,0 +1,793 @@
+package functions
+
+// stdlibFunctions are the functions of OpenTofu/Terraform that Terragrunt
+// makes available, as of the version of Terraform it evaluates them with.
+var stdlibFunctions = []Function{
+	// Numeric functions
+	{
+		Name:        "abs",
+		Parameters:  []string{"num number"},
+		Returns:     "number",
+		Description: "Returns the absolute value of `num`.",
+		Example:     `abs(-12.4) # 12.4`,
+	},
+	{
+		Name:        "ceil",
+		Parameters:  []string{"num number"},
+		Returns:     "number",
+		Description: "Returns the closest whole number that is greater than or equal to `num`.",
+		Example:     `ceil(5.1) # 6`,
+	},
+	{
+		Name:        "floor",
+		Parameters:  []string{"num number"},
+		Returns:     "number",
+		Description: "Returns the closest whole number that is less than or equal to `num`.",
+		Example:     `floor(4.9) # 4`,
+	},
+	{
+		Name:        "log",
+		Parameters:  []string{"num number", "base number"},
+		Returns:     "number",
+		Description: "Returns the logarithm of `num` in `base`.",
+		Example:     `log(16, 2) # 4`,
+	},
+	{
+		Name:        "max",
+		Parameters:  []string{"...nums number"},
+		Returns:     "number",
+		Description: "Returns the greatest of `nums`. Expand a list with `...`.",
+		Example:     `max([12, 54, 3]...) # 54`,
+	},
+	{
+		Name:        "min",
+		Parameters:  []string{"...nums number"},
+		Returns:     "number",
+		Description: "Returns the smallest of `nums`. Expand a list with `...`.",
+		Example:     `min(12, 54, 3) # 3`,
+	},
+	{
+		Name:        "parseint",
+		Parameters:  []string{"str string", "base number"},
+		Returns:     "number",
+		Description: "Parses `str` as an integer in `base`, between 2 and 62.",
+		Example:     `parseint("FF", 16) # 255`,
+	},
+	{
+		Name:        "pow",
+		Parameters:  []string{"num number", "power number"},
+		Returns:     "number",
+		Description: "Returns `num` raised to `power`.",
+		Example:     `pow(3, 2) # 9`,
+	},
+	{
+		Name:        "signum",
+		Parameters:  []string{"num number"},
+		Returns:     "number",
+		Description: "Returns `-1`, `0` or `1` depending on the sign of `num`.",
+		Example:     `signum(-13) # -1`,
+	},
+
+	// String functions
+	{
+		Name:        "chomp",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Removes the newline characters at the end of `str`.",
+		Example:     `chomp("hello\n") # "hello"`,
+	},
+	{
+		Name:        "format",
+		Parameters:  []string{"spec string", "...values any"},
+		Returns:     "string",
+		Description: "Formats `values` according to `spec`, like `printf`.",
+		Example:     `format("%s-%03d", "web", 7) # "web-007"`,
+	},
+	{
+		Name:        "formatlist",
+		Parameters:  []string{"spec string", "...values any"},
+		Returns:     "list(string)",
+		Description: "Formats each element of the lists in `values` according to `spec`, and returns the list of the results.",
+		Example:     `formatlist("Hello, %s!", ["Valentina", "Ander"])`,
+	},
+	{
+		Name:        "indent",
+		Parameters:  []string{"num_spaces number", "str string"},
+		Returns:     "string",
+		Description: "Adds `num_spaces` spaces to the start of every line of `str` but the first.",
+		Example:     `indent(2, "[\n  foo,\n]")`,
+	},
+	{
+		Name:        "join",
+		Parameters:  []string{"separator string", "...lists list(string)"},
+		Returns:     "string",
+		Description: "Concatenates the elements of `lists` with `separator` between them.",
+		Example:     `join(", ", ["foo", "bar", "baz"]) # "foo, bar, baz"`,
+	},
+	{
+		Name:        "lower",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Converts the letters of `str` to lowercase.",
+		Example:     `lower("HELLO") # "hello"`,
+	},
+	{
+		Name:        "regex",
+		Parameters:  []string{"pattern string", "str string"},
+		Returns:     "any",
+		Description: "Returns the first match of the regular expression `pattern` in `str`. With capture groups, returns a list of the groups, or an object for named groups. Fails when there is no match.",
+		Example:     `regex("^(\\d{4})-(\\d{2})", "2024-05-01") # ["2024", "05"]`,
+	},
+	{
+		Name:        "regexall",
+		Parameters:  []string{"pattern string", "str string"},
+		Returns:     "list(any)",
+		Description: "Returns every match of the regular expression `pattern` in `str`, as `regex` would return them.",
+		Example:     `length(regexall("[a-z]+", "1101 zyx 23 abc")) # 2`,
+	},
+	{
+		Name:        "replace",
+		Parameters:  []string{"str string", "substr string", "replacement string"},
+		Returns:     "string",
+		Description: "Replaces every occurrence of `substr` in `str` with `replacement`. When `substr` is wrapped in slashes, it is a regular expression.",
+		Example:     `replace("1 + 2 + 3", "+", "-") # "1 - 2 - 3"`,
+	},
+	{
+		Name:        "split",
+		Parameters:  []string{"separator string", "str string"},
+		Returns:     "list(string)",
+		Description: "Splits `str` at every occurrence of `separator`.",
+		Example:     `split(",", "foo,bar,baz") # ["foo", "bar", "baz"]`,
+	},
+	{
+		Name:        "strrev",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Reverses the characters of `str`.",
+		Example:     `strrev("hello") # "olleh"`,
+	},
+	{
+		Name:        "substr",
+		Parameters:  []string{"str string", "offset number", "length number"},
+		Returns:     "string",
+		Description: "Returns the `length` characters of `str` from `offset`. A `length` of `-1` extends to the end of `str`.",
+		Example:     `substr("hello world", 1, 4) # "ello"`,
+	},
+	{
+		Name:        "title",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Converts the first letter of each word of `str` to uppercase.",
+		Example:     `title("hello world") # "Hello World"`,
+	},
+	{
+		Name:        "trim",
+		Parameters:  []string{"str string", "chars string"},
+		Returns:     "string",
+		Description: "Removes the characters in `chars` from the start and the end of `str`.",
+		Example:     `trim("?!hello?!", "!?") # "hello"`,
+	},
+	{
+		Name:        "trimprefix",
+		Parameters:  []string{"str string", "prefix string"},
+		Returns:     "string",
+		Description: "Removes `prefix` from the start of `str`.",
+		Example:     `trimprefix("helloworld", "hello") # "world"`,
+	},
+	{
+		Name:        "trimsuffix",
+		Parameters:  []string{"str string", "suffix string"},
+		Returns:     "string",
+		Description: "Removes `suffix` from the end of `str`.",
+		Example:     `trimsuffix("helloworld", "world") # "hello"`,
+	},
+	{
+		Name:        "trimspace",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Removes the whitespace at the start and the end of `str`.",
+		Example:     `trimspace("  hello\n\n") # "hello"`,
+	},
+	{
+		Name:        "upper",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Converts the letters of `str` to uppercase.",
+		Example:     `upper("hello") # "HELLO"`,
+	},
+
+	// Collection functions
+	{
+		Name:        "alltrue",
+		Parameters:  []string{"list list(bool)"},
+		Returns:     "bool",
+		Description: "Returns whether every element of `list` is `true`, which it is when `list` is empty.",
+		Example:     `alltrue([true, false]) # false`,
+	},
+	{
+		Name:        "anytrue",
+		Parameters:  []string{"list list(bool)"},
+		Returns:     "bool",
+		Description: "Returns whether any element of `list` is `true`, which it is not when `list` is empty.",
+		Example:     `anytrue([true, false]) # true`,
+	},
+	{
+		Name:        "chunklist",
+		Parameters:  []string{"list list(any)", "size number"},
+		Returns:     "list(list(any))",
+		Description: "Splits `list` into lists of at most `size` elements.",
+		Example:     `chunklist(["a", "b", "c"], 2) # [["a", "b"], ["c"]]`,
+	},
+	{
+		Name:        "coalesce",
+		Parameters:  []string{"...values any"},
+		Returns:     "any",
+		Description: "Returns the first of `values` that is not null or an empty string.",
+		Example:     `coalesce(local.name, "default")`,
+	},
+	{
+		Name:        "coalescelist",
+		Parameters:  []string{"...lists list(any)"},
+		Returns:     "list(any)",
+		Description: "Returns the first of `lists` that is not empty.",
+		Example:     `coalescelist(local.subnets, ["10.0.1.0/24"])`,
+	},
+	{
+		Name:        "compact",
+		Parameters:  []string{"list list(string)"},
+		Returns:     "list(string)",
+		Description: "Removes the empty strings from `list`.",
+		Example:     `compact(["a", "", "b"]) # ["a", "b"]`,
+	},
+	{
+		Name:        "concat",
+		Parameters:  []string{"...lists list(any)"},
+		Returns:     "list(any)",
+		Description: "Concatenates `lists` into a single list.",
+		Example:     `concat(["a"], ["b", "c"]) # ["a", "b", "c"]`,
+	},
+	{
+		Name:        "contains",
+		Parameters:  []string{"list list(any)", "value any"},
+		Returns:     "bool",
+		Description: "Returns whether `list` contains `value`.",
+		Example:     `contains(["dev", "prod"], local.env)`,
+	},
+	{
+		Name:        "distinct",
+		Parameters:  []string{"list list(any)"},
+		Returns:     "list(any)",
+		Description: "Removes the duplicate elements of `list`, keeping the first occurrence of each.",
+		Example:     `distinct(["a", "b", "a"]) # ["a", "b"]`,
+	},
+	{
+		Name:        "element",
+		Parameters:  []string{"list list(any)", "index number"},
+		Returns:     "any",
+		Description: "Returns the element of `list` at `index`, wrapping around when `index` is past the end of `list`.",
+		Example:     `element(["a", "b", "c"], 3) # "a"`,
+	},
+	{
+		Name:        "flatten",
+		Parameters:  []string{"list list(any)"},
+		Returns:     "list(any)",
+		Description: "Replaces the lists nested in `list` with their elements, recursively.",
+		Example:     `flatten([["a", "b"], [], ["c"]]) # ["a", "b", "c"]`,
+	},
+	{
+		Name:        "index",
+		Parameters:  []string{"list list(any)", "value any"},
+		Returns:     "number",
+		Description: "Returns the index of the first element of `list` equal to `value`. Fails when there is none.",
+		Example:     `index(["a", "b", "c"], "b") # 1`,
+	},
+	{
+		Name:        "keys",
+		Parameters:  []string{"map map(any)"},
+		Returns:     "list(string)",
+		Description: "Returns the keys of `map`, in lexical order.",
+		Example:     `keys({a = 1, c = 2, d = 3}) # ["a", "c", "d"]`,
+	},
+	{
+		Name:        "length",
+		Parameters:  []string{"value any"},
+		Returns:     "number",
+		Description: "Returns the number of elements of a list, map or object, or the number of characters of a string.",
+		Example:     `length(["a", "b"]) # 2`,
+	},
+	{
+		Name:        "lookup",
+		Parameters:  []string{"map map(any)", "key string", "default? any"},
+		Returns:     "any",
+		Description: "Returns the value of `key` in `map`, or `default` when `map` has no such key.",
+		Example:     `lookup(local.instance_types, local.env, "t3.micro")`,
+	},
+	{
+		Name:        "matchkeys",
+		Parameters:  []string{"values list(any)", "keys list(any)", "search list(any)"},
+		Returns:     "list(any)",
+		Description: "Returns the elements of `values` whose element at the same index in `keys` is in `search`.",
+		Example:     `matchkeys(["i-1", "i-2"], ["us-west", "us-east"], ["us-east"]) # ["i-2"]`,
+	},
+	{
+		Name:        "merge",
+		Parameters:  []string{"...maps map(any)"},
+		Returns:     "map(any)",
+		Description: "Merges `maps` into a single map. Later keys take precedence over earlier ones, and nested maps are not merged.",
+		Example:     `inputs = merge(local.common_inputs, { name = "app" })`,
+	},
+	{
+		Name:        "one",
+		Parameters:  []string{"list list(any)"},
+		Returns:     "any",
+		Description: "Returns the only element of `list`, or null when it is empty. Fails when it has more than one element.",
+		Example:     `one(["a"]) # "a"`,
+	},
+	{
+		Name:        "range",
+		Parameters:  []string{"start? number", "limit number", "step? number"},
+		Returns:     "list(number)",
+		Description: "Returns the numbers from `start`, which defaults to `0`, up to but not including `limit`, in increments of `step`, which defaults to `1`.",
+		Example:     `range(1, 4) # [1, 2, 3]`,
+	},
+	{
+		Name:        "reverse",
+		Parameters:  []string{"list list(any)"},
+		Returns:     "list(any)",
+		Description: "Reverses the order of the elements of `list`.",
+		Example:     `reverse([1, 2, 3]) # [3, 2, 1]`,
+	},
+	{
+		Name:        "setintersection",
+		Parameters:  []string{"...sets set(any)"},
+		Returns:     "set(any)",
+		Description: "Returns the elements that are in every one of `sets`.",
+		Example:     `setintersection(["a", "b"], ["b", "c"]) # ["b"]`,
+	},
+	{
+		Name:        "setproduct",
+		Parameters:  []string{"...sets set(any)"},
+		Returns:     "list(list(any))",
+		Description: "Returns every combination of one element from each of `sets`.",
+		Example:     `setproduct(["dev", "prod"], ["app", "db"])`,
+	},
+	{
+		Name:        "setsubtract",
+		Parameters:  []string{"a set(any)", "b set(any)"},
+		Returns:     "set(any)",
+		Description: "Returns the elements of `a` that are not in `b`.",
+		Example:     `setsubtract(["a", "b", "c"], ["a", "c"]) # ["b"]`,
+	},
+	{
+		Name:        "setunion",
+		Parameters:  []string{"...sets set(any)"},
+		Returns:     "set(any)",
+		Description: "Returns the elements that are in any of `sets`.",
+		Example:     `setunion(["a", "b"], ["b", "c"]) # ["a", "b", "c"]`,
+	},
+	{
+		Name:        "slice",
+		Parameters:  []string{"list list(any)", "start number", "end number"},
+		Returns:     "list(any)",
+		Description: "Returns the elements of `list` from index `start`, up to but not including index `end`.",
+		Example:     `slice(["a", "b", "c", "d"], 1, 3) # ["b", "c"]`,
+	},
+	{
+		Name:        "sort",
+		Parameters:  []string{"list list(string)"},
+		Returns:     "list(string)",
+		Description: "Sorts the strings of `list` in lexical order.",
+		Example:     `sort(["e", "d", "a"]) # ["a", "d", "e"]`,
+	},
+	{
+		Name:        "sum",
+		Parameters:  []string{"list list(number)"},
+		Returns:     "number",
+		Description: "Returns the sum of the numbers of `list`.",
+		Example:     `sum([10, 13, 6]) # 29`,
+	},
+	{
+		Name:        "transpose",
+		Parameters:  []string{"map map(list(string))"},
+		Returns:     "map(list(string))",
+		Description: "Swaps the keys and the values of a map of lists of strings.",
+		Example:     `transpose({a = ["1", "2"], b = ["2"]}) # {"1" = ["a"], "2" = ["a", "b"]}`,
+	},
+	{
+		Name:        "values",
+		Parameters:  []string{"map map(any)"},
+		Returns:     "list(any)",
+		Description: "Returns the values of `map`, in the lexical order of their keys.",
+		Example:     `values({a = 3, c = 2, d = 1}) # [3, 2, 1]`,
+	},
+	{
+		Name:        "zipmap",
+		Parameters:  []string{"keys list(string)", "values list(any)"},
+		Returns:     "map(any)",
+		Description: "Builds a map from a list of `keys` and a list of `values` of the same length.",
+		Example:     `zipmap(["a", "b"], [1, 2]) # {a = 1, b = 2}`,
+	},
+
+	// Encoding functions
+	{
+		Name:        "base64decode",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Decodes a Base64 string into a UTF-8 string.",
+		Example:     `base64decode("SGVsbG8=") # "Hello"`,
+	},
+	{
+		Name:        "base64encode",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Encodes a UTF-8 string in Base64.",
+		Example:     `base64encode("Hello") # "SGVsbG8="`,
+	},
+	{
+		Name:        "base64gzip",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Compresses a UTF-8 string with gzip and encodes the result in Base64.",
+		Example:     `base64gzip(file("script.sh"))`,
+	},
+	{
+		Name:        "csvdecode",
+		Parameters:  []string{"str string"},
+		Returns:     "list(object)",
+		Description: "Decodes a CSV string into a list of objects, one per row, keyed by the names in the header row.",
+		Example:     `csvdecode(file("${get_terragrunt_dir()}/users.csv"))`,
+	},
+	{
+		Name:        "jsondecode",
+		Parameters:  []string{"str string"},
+		Returns:     "any",
+		Description: "Decodes a JSON string into the equivalent HCL value.",
+		Example:     `jsondecode(file("config.json"))`,
+	},
+	{
+		Name:        "jsonencode",
+		Parameters:  []string{"value any"},
+		Returns:     "string",
+		Description: "Encodes `value` as JSON.",
+		Example:     `jsonencode({ name = "app" }) # "{\"name\":\"app\"}"`,
+	},
+	{
+		Name:        "textdecodebase64",
+		Parameters:  []string{"str string", "encoding string"},
+		Returns:     "string",
+		Description: "Decodes a Base64 string holding text in `encoding`, such as `UTF-16LE`.",
+		Example:     `textdecodebase64("SABlAGwAbABvAA==", "UTF-16LE") # "Hello"`,
+	},
+	{
+		Name:        "textencodebase64",
+		Parameters:  []string{"str string", "encoding string"},
+		Returns:     "string",
+		Description: "Encodes `str` in `encoding`, such as `UTF-16LE`, and the result in Base64.",
+		Example:     `textencodebase64("Hello", "UTF-16LE") # "SABlAGwAbABvAA=="`,
+	},
+	{
+		Name:        "urlencode",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Escapes `str` to be used in a URL query string.",
+		Example:     `urlencode("Hello World!") # "Hello+World%21"`,
+	},
+	{
+		Name:        "yamldecode",
+		Parameters:  []string{"str string"},
+		Returns:     "any",
+		Description: "Decodes a YAML string into the equivalent HCL value.",
+		Example: `locals {
+  env = yamldecode(file(find_in_parent_folders("env.yaml")))
+}`,
+	},
+	{
+		Name:        "yamlencode",
+		Parameters:  []string{"value any"},
+		Returns:     "string",
+		Description: "Encodes `value` as YAML.",
+		Example:     `yamlencode({ name = "app" }) # "\"name\": \"app\"\n"`,
+	},
+
+	// Filesystem functions
+	{
+		Name:        "abspath",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Converts `path` to an absolute path, relative to the working directory when it is relative.",
+		Example:     `abspath("../modules")`,
+	},
+	{
+		Name:        "basename",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the last element of `path`.",
+		Example:     `basename(get_terragrunt_dir()) # "vpc"`,
+	},
+	{
+		Name:        "dirname",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns `path` without its last element.",
+		Example:     `dirname(find_in_parent_folders("root.hcl"))`,
+	},
+	{
+		Name:        "pathexpand",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Replaces a leading `~` in `path` with the home directory of the current user.",
+		Example:     `pathexpand("~/.ssh/id_rsa.pub")`,
+	},
+	{
+		Name:        "file",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the content of the file at `path`, which must be UTF-8. Relative paths are relative to the directory of the configuration.",
+		Example:     `policy = file("${get_terragrunt_dir()}/policy.json")`,
+	},
+	{
+		Name:        "fileexists",
+		Parameters:  []string{"path string"},
+		Returns:     "bool",
+		Description: "Returns whether a file exists at `path`.",
+		Example:     `fileexists("${get_terragrunt_dir()}/override.tfvars")`,
+	},
+	{
+		Name:        "fileset",
+		Parameters:  []string{"path string", "pattern string"},
+		Returns:     "set(string)",
+		Description: "Returns the paths of the files under `path` that match the glob `pattern`, relative to `path`.",
+		Example:     `fileset(get_terragrunt_dir(), "*.tfvars")`,
+	},
+	{
+		Name:        "filebase64",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the content of the file at `path`, encoded in Base64.",
+		Example:     `filebase64("${get_terragrunt_dir()}/cert.der")`,
+	},
+	{
+		Name:        "templatefile",
+		Parameters:  []string{"path string", "vars map(any)"},
+		Returns:     "string",
+		Description: "Renders the template file at `path` with `vars`.",
+		Example:     `templatefile("${get_terragrunt_dir()}/backend.tftpl", { bucket = local.bucket })`,
+	},
+
+	// Date and time functions
+	{
+		Name:        "formatdate",
+		Parameters:  []string{"spec string", "timestamp string"},
+		Returns:     "string",
+		Description: "Formats an RFC 3339 `timestamp` according to `spec`.",
+		Example:     `formatdate("YYYY-MM-DD", timestamp())`,
+	},
+	{
+		Name:        "timeadd",
+		Parameters:  []string{"timestamp string", "duration string"},
+		Returns:     "string",
+		Description: "Adds `duration`, such as `1h30m`, to an RFC 3339 `timestamp`.",
+		Example:     `timeadd("2024-01-01T00:00:00Z", "24h") # "2024-01-02T00:00:00Z"`,
+	},
+	{
+		Name:        "timestamp",
+		Returns:     "string",
+		Description: "Returns the current date and time in UTC, in RFC 3339 format.",
+		Example:     `created_at = timestamp()`,
+	},
+
+	// Hash and crypto functions
+	{
+		Name:        "base64sha256",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Returns the SHA256 hash of `str`, encoded in Base64.",
+		Example:     `base64sha256("hello")`,
+	},
+	{
+		Name:        "base64sha512",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Returns the SHA512 hash of `str`, encoded in Base64.",
+		Example:     `base64sha512("hello")`,
+	},
+	{
+		Name:        "bcrypt",
+		Parameters:  []string{"str string", "cost? number"},
+		Returns:     "string",
+		Description: "Hashes `str` with Blowfish, with a `cost` of 10 by default. The salt is random, so the result changes on every call.",
+		Example:     `bcrypt("hello")`,
+	},
+	{
+		Name:        "filebase64sha256",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the SHA256 hash of the content of the file at `path`, encoded in Base64.",
+		Example:     `filebase64sha256("lambda.zip")`,
+	},
+	{
+		Name:        "filebase64sha512",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the SHA512 hash of the content of the file at `path`, encoded in Base64.",
+		Example:     `filebase64sha512("lambda.zip")`,
+	},
+	{
+		Name:        "filemd5",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the MD5 hash of the content of the file at `path`, in hexadecimal.",
+		Example:     `filemd5("lambda.zip")`,
+	},
+	{
+		Name:        "filesha1",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the SHA1 hash of the content of the file at `path`, in hexadecimal.",
+		Example:     `filesha1("lambda.zip")`,
+	},
+	{
+		Name:        "filesha256",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the SHA256 hash of the content of the file at `path`, in hexadecimal.",
+		Example:     `filesha256("lambda.zip")`,
+	},
+	{
+		Name:        "filesha512",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Returns the SHA512 hash of the content of the file at `path`, in hexadecimal.",
+		Example:     `filesha512("lambda.zip")`,
+	},
+	{
+		Name:        "md5",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Returns the MD5 hash of `str`, in hexadecimal.",
+		Example:     `md5("hello")`,
+	},
+	{
+		Name:        "rsadecrypt",
+		Parameters:  []string{"ciphertext string", "privatekey string"},
+		Returns:     "string",
+		Description: "Decrypts a Base64 `ciphertext` encrypted with RSA, using a PEM `privatekey`.",
+		Example:     `rsadecrypt(local.encrypted_password, file("key.pem"))`,
+	},
+	{
+		Name:        "sha1",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Returns the SHA1 hash of `str`, in hexadecimal.",
+		Example:     `sha1("hello")`,
+	},
+	{
+		Name:        "sha256",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Returns the SHA256 hash of `str`, in hexadecimal.",
+		Example:     `sha256("hello")`,
+	},
+	{
+		Name:        "sha512",
+		Parameters:  []string{"str string"},
+		Returns:     "string",
+		Description: "Returns the SHA512 hash of `str`, in hexadecimal.",
+		Example:     `sha512("hello")`,
+	},
+	{
+		Name:        "uuid",
+		Returns:     "string",
+		Description: "Returns a random UUID, which changes on every call.",
+		Example:     `id = uuid()`,
+	},
+	{
+		Name:        "uuidv5",
+		Parameters:  []string{"namespace string", "name string"},
+		Returns:     "string",
+		Description: "Returns the name-based UUID of `name` in `namespace`, which is `dns`, `url`, `oid`, `x500` or a UUID.",
+		Example:     `uuidv5("dns", "example.com")`,
+	},
+
+	// IP network functions
+	{
+		Name:        "cidrhost",
+		Parameters:  []string{"prefix string", "hostnum number"},
+		Returns:     "string",
+		Description: "Returns the IP address of host number `hostnum` in the CIDR `prefix`.",
+		Example:     `cidrhost("10.12.112.0/20", 16) # "10.12.112.16"`,
+	},
+	{
+		Name:        "cidrnetmask",
+		Parameters:  []string{"prefix string"},
+		Returns:     "string",
+		Description: "Returns the netmask of an IPv4 CIDR `prefix`.",
+		Example:     `cidrnetmask("172.16.0.0/12") # "255.240.0.0"`,
+	},
+	{
+		Name:        "cidrsubnet",
+		Parameters:  []string{"prefix string", "newbits number", "netnum number"},
+		Returns:     "string",
+		Description: "Returns subnet number `netnum` of the CIDR `prefix`, extended by `newbits` bits.",
+		Example:     `cidrsubnet("10.0.0.0/16", 8, 2) # "10.0.2.0/24"`,
+	},
+	{
+		Name:        "cidrsubnets",
+		Parameters:  []string{"prefix string", "...newbits number"},
+		Returns:     "list(string)",
+		Description: "Returns consecutive subnets of the CIDR `prefix`, each extended by the matching `newbits`.",
+		Example:     `cidrsubnets("10.1.0.0/16", 4, 4, 8) # ["10.1.0.0/20", "10.1.16.0/20", "10.1.32.0/24"]`,
+	},
+
+	// Type conversion functions
+	{
+		Name:        "can",
+		Parameters:  []string{"expression any"},
+		Returns:     "bool",
+		Description: "Returns whether `expression` evaluates without errors.",
+		Example:     `can(regex("^ami-", local.ami_id))`,
+	},
+	{
+		Name:        "nonsensitive",
+		Parameters:  []string{"value any"},
+		Returns:     "any",
+		Description: "Returns `value` without its sensitive marking.",
+		Example:     `nonsensitive(sha256(local.password))`,
+	},
+	{
+		Name:        "sensitive",
+		Parameters:  []string{"value any"},
+		Returns:     "any",
+		Description: "Returns `value` marked as sensitive.",
+		Example:     `sensitive(local.password)`,
+	},
+	{
+		Name:        "tobool",
+		Parameters:  []string{"value any"},
+		Returns:     "bool",
+		Description: "Converts `value` to a bool. Only `true`, `false`, `\"true\"`, `\"false\"` and null convert.",
+		Example:     `tobool(get_env("ENABLED", "false"))`,
+	},
+	{
+		Name:        "tolist",
+		Parameters:  []string{"value any"},
+		Returns:     "list(any)",
+		Description: "Converts a set or tuple to a list.",
+		Example:     `tolist(["a", "b"])`,
+	},
+	{
+		Name:        "tomap",
+		Parameters:  []string{"value any"},
+		Returns:     "map(any)",
+		Description: "Converts an object to a map.",
+		Example:     `tomap({ a = 1, b = 2 })`,
+	},
+	{
+		Name:        "tonumber",
+		Parameters:  []string{"value any"},
+		Returns:     "number",
+		Description: "Converts `value` to a number.",
+		Example:     `tonumber(get_env("REPLICAS", "1"))`,
+	},
+	{
+		Name:        "toset",
+		Parameters:  []string{"value any"},
+		Returns:     "set(any)",
+		Description: "Converts a list or tuple to a set, removing duplicates and ordering.",
+		Example:     `toset(["a", "b", "a"]) # ["a", "b"]`,
+	},
+	{
+		Name:        "tostring",
+		Parameters:  []string{"value any"},
+		Returns:     "string",
+		Description: "Converts a number or bool to a string.",
+		Example:     `tostring(42) # "42"`,
+	},
+	{
+		Name:        "try",
+		Parameters:  []string{"...expressions any"},
+		Returns:     "any",
+		Description: "Returns the result of the first of `expressions` that evaluates without errors.",
+		Example:     `try(local.config.region, "us-east-1")`,
+	},
+}
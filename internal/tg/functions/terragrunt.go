@@ -0,0 +1,249 @@
+package functions
+
+// terragruntFunctions are the built-in functions of Terragrunt.
+var terragruntFunctions = []Function{
+	{
+		Name:        "find_in_parent_folders",
+		Parameters:  []string{"name? string", "fallback? string"},
+		Returns:     "string",
+		Description: "Searches up the directory tree from the current configuration and returns the absolute path to the first file or folder called `name`. Returns `fallback` when nothing is found, and fails when no fallback is given. Without `name`, it searches for `terragrunt.hcl`, which is deprecated in favor of naming the root configuration, such as `root.hcl`.",
+		Example: `include "root" {
+  path = find_in_parent_folders("root.hcl")
+}`,
+	},
+	{
+		Name:        "path_relative_to_include",
+		Parameters:  []string{"include_name? string"},
+		Returns:     "string",
+		Description: "Returns the relative path between the directory of the included configuration and the directory of the current configuration. With several includes, `include_name` selects the include to use.",
+		Example:     `key = "${path_relative_to_include()}/terraform.tfstate"`,
+	},
+	{
+		Name:        "path_relative_from_include",
+		Parameters:  []string{"include_name? string"},
+		Returns:     "string",
+		Description: "Returns the relative path between the directory of the current configuration and the directory of the included configuration. It is the opposite of `path_relative_to_include`.",
+		Example:     `source = "${path_relative_from_include()}/../modules//vpc"`,
+	},
+	{
+		Name:        "get_env",
+		Parameters:  []string{"name string", "default? string"},
+		Returns:     "string",
+		Description: "Returns the value of the environment variable `name`, or `default` when it is not set. Fails when the variable is not set and no default is given.",
+		Example:     `bucket = get_env("TF_STATE_BUCKET", "my-terraform-state")`,
+	},
+	{
+		Name:        "get_platform",
+		Returns:     "string",
+		Description: "Returns the operating system Terragrunt is running on, such as `linux`, `darwin` or `windows`.",
+		Example:     `binary = get_platform() == "windows" ? "tool.exe" : "tool"`,
+	},
+	{
+		Name:        "get_repo_root",
+		Returns:     "string",
+		Description: "Returns the absolute path to the root of the Git repository the configuration is in.",
+		Example:     `config = "${get_repo_root()}/config/common.yaml"`,
+	},
+	{
+		Name:        "get_path_from_repo_root",
+		Returns:     "string",
+		Description: "Returns the path of the directory of the current configuration, relative to the root of the Git repository.",
+		Example:     `key = "${get_path_from_repo_root()}/terraform.tfstate"`,
+	},
+	{
+		Name:        "get_path_to_repo_root",
+		Returns:     "string",
+		Description: "Returns the relative path from the directory of the current configuration to the root of the Git repository.",
+		Example:     `source = "${get_path_to_repo_root()}//modules/vpc"`,
+	},
+	{
+		Name:        "get_terragrunt_dir",
+		Returns:     "string",
+		Description: "Returns the absolute path to the directory of the current configuration. In an included file, it is the directory of the configuration that includes it.",
+		Example: `extra_arguments "vars" {
+  commands  = get_terraform_commands_that_need_vars()
+  arguments = ["-var-file=${get_terragrunt_dir()}/common.tfvars"]
+}`,
+	},
+	{
+		Name:        "get_original_terragrunt_dir",
+		Returns:     "string",
+		Description: "Returns the absolute path to the directory of the configuration Terragrunt was run on, even when called from a configuration read with `read_terragrunt_config`.",
+		Example:     `unit_dir = get_original_terragrunt_dir()`,
+	},
+	{
+		Name:        "get_parent_terragrunt_dir",
+		Parameters:  []string{"include_name? string"},
+		Returns:     "string",
+		Description: "Returns the absolute path to the directory of the included configuration. With several includes, `include_name` selects the include to use.",
+		Example:     `source = "${get_parent_terragrunt_dir()}/modules//vpc"`,
+	},
+	{
+		Name:        "get_working_dir",
+		Returns:     "string",
+		Description: "Returns the absolute path to the directory Terragrunt runs OpenTofu/Terraform in, such as the module copy under `.terragrunt-cache`.",
+		Example:     `plugin_dir = "${get_working_dir()}/.plugins"`,
+	},
+	{
+		Name:        "get_terraform_command",
+		Returns:     "string",
+		Description: "Returns the OpenTofu/Terraform command Terragrunt is running, such as `plan` or `apply`.",
+		Example:     `lock = get_terraform_command() == "apply"`,
+	},
+	{
+		Name:        "get_terraform_cli_args",
+		Returns:     "list(string)",
+		Description: "Returns the arguments passed to the OpenTofu/Terraform command Terragrunt is running.",
+		Example:     `args = get_terraform_cli_args()`,
+	},
+	{
+		Name:        "get_terraform_commands_that_need_vars",
+		Returns:     "list(string)",
+		Description: "Returns the OpenTofu/Terraform commands that accept `-var` and `-var-file`.",
+		Example: `extra_arguments "common_vars" {
+  commands  = get_terraform_commands_that_need_vars()
+  arguments = ["-var-file=common.tfvars"]
+}`,
+	},
+	{
+		Name:        "get_terraform_commands_that_need_locking",
+		Returns:     "list(string)",
+		Description: "Returns the OpenTofu/Terraform commands that accept `-lock-timeout`.",
+		Example: `extra_arguments "retry_lock" {
+  commands  = get_terraform_commands_that_need_locking()
+  arguments = ["-lock-timeout=20m"]
+}`,
+	},
+	{
+		Name:        "get_terraform_commands_that_need_input",
+		Returns:     "list(string)",
+		Description: "Returns the OpenTofu/Terraform commands that accept `-input`.",
+		Example: `extra_arguments "no_input" {
+  commands  = get_terraform_commands_that_need_input()
+  arguments = ["-input=false"]
+}`,
+	},
+	{
+		Name:        "get_terraform_commands_that_need_parallelism",
+		Returns:     "list(string)",
+		Description: "Returns the OpenTofu/Terraform commands that accept `-parallelism`.",
+		Example: `extra_arguments "parallelism" {
+  commands  = get_terraform_commands_that_need_parallelism()
+  arguments = ["-parallelism=5"]
+}`,
+	},
+	{
+		Name:        "get_aws_account_alias",
+		Returns:     "string",
+		Description: "Returns the alias of the AWS account of the current credentials, or an empty string when it has none.",
+		Example:     `account = get_aws_account_alias()`,
+	},
+	{
+		Name:        "get_aws_account_id",
+		Returns:     "string",
+		Description: "Returns the ID of the AWS account of the current credentials.",
+		Example:     `bucket = "terraform-state-${get_aws_account_id()}"`,
+	},
+	{
+		Name:        "get_aws_caller_identity_arn",
+		Returns:     "string",
+		Description: "Returns the ARN of the AWS identity of the current credentials.",
+		Example:     `caller_arn = get_aws_caller_identity_arn()`,
+	},
+	{
+		Name:        "get_aws_caller_identity_user_id",
+		Returns:     "string",
+		Description: "Returns the user ID of the AWS identity of the current credentials.",
+		Example:     `caller_user_id = get_aws_caller_identity_user_id()`,
+	},
+	{
+		Name:        "run_cmd",
+		Parameters:  []string{"...args string"},
+		Returns:     "string",
+		Description: "Runs a shell command and returns its standard output, with trailing whitespace removed. Leading options change how it runs: `--terragrunt-quiet` hides the output from the logs, and `--terragrunt-global-cache` caches the result across configurations. The result is cached for the same arguments in a directory.",
+		Example:     `region = run_cmd("--terragrunt-quiet", "./scripts/region.sh", "prod")`,
+	},
+	{
+		Name:        "read_terragrunt_config",
+		Parameters:  []string{"config_path string", "default? any"},
+		Returns:     "object",
+		Description: "Parses the Terragrunt configuration at `config_path` and returns its blocks and attributes, such as `locals`, `inputs` and the outputs of its dependencies. Returns `default` when the file does not exist.",
+		Example: `locals {
+  common = read_terragrunt_config(find_in_parent_folders("common.hcl"))
+}`,
+	},
+	{
+		Name:        "read_tfvars_file",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Reads a `.tfvars` or `.tfvars.json` file and returns its variables as a JSON string, to be decoded with `jsondecode`.",
+		Example:     `inputs = jsondecode(read_tfvars_file("common.tfvars"))`,
+	},
+	{
+		Name:        "sops_decrypt_file",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Decrypts a file encrypted with SOPS and returns its content. The format is taken from the extension of the file, and defaults to JSON.",
+		Example: `locals {
+  secrets = yamldecode(sops_decrypt_file("secrets.yaml"))
+}`,
+	},
+	{
+		Name:        "get_terragrunt_source_cli_flag",
+		Returns:     "string",
+		Description: "Returns the value of the `--source` flag passed to Terragrunt, or an empty string when it is not set.",
+		Example:     `local_dev = get_terragrunt_source_cli_flag() != ""`,
+	},
+	{
+		Name:        "get_default_retryable_errors",
+		Returns:     "list(string)",
+		Description: "Returns the regular expressions of the errors Terragrunt retries by default.",
+		Example: `errors {
+  retry "transient" {
+    retryable_errors = get_default_retryable_errors()
+  }
+}`,
+	},
+	{
+		Name:        "mark_as_read",
+		Parameters:  []string{"path string"},
+		Returns:     "string",
+		Description: "Marks a file as read by the configuration, so that `--queue-include-units-reading` picks up the unit when the file changes. Returns `path`.",
+		Example:     `config = file(mark_as_read("${get_repo_root()}/config.yaml"))`,
+	},
+	{
+		Name:        "constraint_check",
+		Parameters:  []string{"version string", "constraint string"},
+		Returns:     "bool",
+		Description: "Returns whether `version` satisfies the version `constraint`, such as `>= 1.2`.",
+		Example:     `new_provider = constraint_check(local.provider_version, ">= 5.0")`,
+	},
+	{
+		Name:        "startswith",
+		Parameters:  []string{"str string", "prefix string"},
+		Returns:     "bool",
+		Description: "Returns whether `str` starts with `prefix`.",
+		Example:     `is_prod = startswith(local.env, "prod")`,
+	},
+	{
+		Name:        "endswith",
+		Parameters:  []string{"str string", "suffix string"},
+		Returns:     "bool",
+		Description: "Returns whether `str` ends with `suffix`.",
+		Example:     `is_json = endswith(local.path, ".json")`,
+	},
+	{
+		Name:        "strcontains",
+		Parameters:  []string{"str string", "substr string"},
+		Returns:     "bool",
+		Description: "Returns whether `str` contains `substr`.",
+		Example:     `is_staging = strcontains(get_terragrunt_dir(), "staging")`,
+	},
+	{
+		Name:        "timecmp",
+		Parameters:  []string{"timestamp_a string", "timestamp_b string"},
+		Returns:     "number",
+		Description: "Compares two RFC 3339 timestamps, and returns `-1` when the first is before the second, `0` when they are equal and `1` when it is after.",
+		Example:     `expired = timecmp(timestamp(), local.expires_at) > 0`,
+	},
+}
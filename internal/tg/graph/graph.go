@@ -0,0 +1,194 @@
+// Package graph provides the logic for building the dependency graph of the
+// units of a workspace, and rendering it in the formats other tools read.
+package graph
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// Format is a format the graph can be rendered in.
+type Format string
+
+const (
+	// FormatJSON renders the graph as a Graph object.
+	FormatJSON Format = "json"
+
+	// FormatDOT renders the graph as a Graphviz DOT digraph, like
+	// `terragrunt dag graph` does.
+	FormatDOT Format = "dot"
+
+	// FormatMermaid renders the graph as a Mermaid flowchart.
+	FormatMermaid Format = "mermaid"
+)
+
+// ParseFormat returns the format with the given name. The empty name stands
+// for FormatJSON.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatDOT, FormatMermaid:
+		return format, nil
+	}
+
+	return "", fmt.Errorf("unknown graph format %q, expected one of %q, %q or %q", name, FormatJSON, FormatDOT, FormatMermaid)
+}
+
+// Graph is the dependency graph of the units under a directory.
+type Graph struct {
+	// Units are the units under the directory, and the units outside it
+	// that they depend on, sorted by path.
+	Units []Unit `json:"units"`
+}
+
+// Unit is a unit of the graph.
+type Unit struct {
+	// Path is the slash-separated path of the directory of the unit,
+	// relative to the directory of the graph.
+	Path string `json:"path"`
+
+	// Dependencies are the paths of the units the unit depends on, through
+	// `dependency` and `dependencies` blocks, sorted. They are only known for
+	// the units under the directory of the graph.
+	Dependencies []string `json:"dependencies"`
+}
+
+// Build returns the dependency graph of the units among files that are under
+// root.
+func Build(root string, files []*workspace.File) *Graph {
+	units := map[string][]string{}
+
+	for _, file := range files {
+		if file.FileType != store.FileTypeUnit || !isUnder(root, file.Path) {
+			continue
+		}
+
+		path := unitPath(root, file.Path)
+		dependencies := units[path]
+
+		for _, dep := range file.Dependencies {
+			depPath := unitPath(root, dep.Path)
+			if !slices.Contains(dependencies, depPath) {
+				dependencies = append(dependencies, depPath)
+			}
+		}
+
+		slices.Sort(dependencies)
+
+		units[path] = dependencies
+	}
+
+	// Units outside root are part of the graph as the targets of edges.
+	for _, dependencies := range units {
+		for _, dep := range dependencies {
+			if _, ok := units[dep]; !ok {
+				units[dep] = nil
+			}
+		}
+	}
+
+	g := &Graph{Units: make([]Unit, 0, len(units))}
+
+	for path, dependencies := range units {
+		if dependencies == nil {
+			dependencies = []string{}
+		}
+
+		g.Units = append(g.Units, Unit{Path: path, Dependencies: dependencies})
+	}
+
+	slices.SortFunc(g.Units, func(a, b Unit) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return g
+}
+
+// Render returns the graph in format: the Graph itself for FormatJSON, and
+// the text of the graph otherwise.
+func (g *Graph) Render(format Format) any {
+	switch format {
+	case FormatDOT:
+		return g.DOT()
+	case FormatMermaid:
+		return g.Mermaid()
+	case FormatJSON:
+	}
+
+	return g
+}
+
+// DOT returns the graph as a Graphviz DOT digraph, with an edge from each
+// unit to each of its dependencies.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph {\n")
+
+	for _, unit := range g.Units {
+		fmt.Fprintf(&b, "\t%s;\n", strconv.Quote(unit.Path))
+	}
+
+	for _, unit := range g.Units {
+		for _, dep := range unit.Dependencies {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", strconv.Quote(unit.Path), strconv.Quote(dep))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart, with an edge from each
+// unit to each of its dependencies.
+func (g *Graph) Mermaid() string {
+	ids := make(map[string]string, len(g.Units))
+
+	var b strings.Builder
+
+	b.WriteString("graph TD\n")
+
+	for i, unit := range g.Units {
+		ids[unit.Path] = "u" + strconv.Itoa(i)
+
+		fmt.Fprintf(&b, "\t%s[\"%s\"]\n", ids[unit.Path], strings.ReplaceAll(unit.Path, `"`, "#quot;"))
+	}
+
+	for _, unit := range g.Units {
+		for _, dep := range unit.Dependencies {
+			fmt.Fprintf(&b, "\t%s --> %s\n", ids[unit.Path], ids[dep])
+		}
+	}
+
+	return b.String()
+}
+
+// unitPath returns the slash-separated path of the directory of the unit
+// configuration at configPath, relative to root.
+func unitPath(root, configPath string) string {
+	dir := filepath.Dir(configPath)
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// isUnder reports whether path is inside root.
+func isUnder(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,118 @@
+package graph_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/tg/graph"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// unit returns an indexed unit at dir that depends on the units at deps.
+func unit(dir string, deps ...string) *workspace.File {
+	file := &workspace.File{
+		Path:     filepath.Join(filepath.FromSlash(dir), workspace.UnitConfigFile),
+		FileType: store.FileTypeUnit,
+	}
+
+	for _, dep := range deps {
+		file.Dependencies = append(file.Dependencies, workspace.Dependency{
+			Path: filepath.Join(filepath.FromSlash(dep), workspace.UnitConfigFile),
+		})
+	}
+
+	return file
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	files := []*workspace.File{
+		unit("/live/app", "/live/db", "/live/vpc", "/live/vpc"),
+		unit("/live/db", "/live/vpc"),
+		unit("/live/vpc"),
+		unit("/live/prod/web", "/live/app", "/shared/dns"),
+		{Path: filepath.FromSlash("/live/root.hcl"), FileType: store.FileTypeUnknown},
+		unit("/other/app", "/live/vpc"),
+	}
+
+	t.Run("workspace", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, &graph.Graph{Units: []graph.Unit{
+			{Path: "../shared/dns", Dependencies: []string{}},
+			{Path: "app", Dependencies: []string{"db", "vpc"}},
+			{Path: "db", Dependencies: []string{"vpc"}},
+			{Path: "prod/web", Dependencies: []string{"../shared/dns", "app"}},
+			{Path: "vpc", Dependencies: []string{}},
+		}}, graph.Build(filepath.FromSlash("/live"), files))
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, &graph.Graph{Units: []graph.Unit{
+			{Path: "../../shared/dns", Dependencies: []string{}},
+			{Path: "../app", Dependencies: []string{}},
+			{Path: "web", Dependencies: []string{"../../shared/dns", "../app"}},
+		}}, graph.Build(filepath.FromSlash("/live/prod"), files))
+	})
+}
+
+func TestGraph_Render(t *testing.T) {
+	t.Parallel()
+
+	g := graph.Build(filepath.FromSlash("/live"), []*workspace.File{
+		unit("/live/app", "/live/vpc"),
+		unit("/live/vpc"),
+	})
+
+	tc := []struct {
+		expected any
+		name     string
+		format   graph.Format
+	}{
+		{
+			name:     "json",
+			format:   graph.FormatJSON,
+			expected: g,
+		},
+		{
+			name:     "dot",
+			format:   graph.FormatDOT,
+			expected: "digraph {\n\t\"app\";\n\t\"vpc\";\n\t\"app\" -> \"vpc\";\n}\n",
+		},
+		{
+			name:     "mermaid",
+			format:   graph.FormatMermaid,
+			expected: "graph TD\n\tu0[\"app\"]\n\tu1[\"vpc\"]\n\tu0 --> u1\n",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, g.Render(tt.format))
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := graph.ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, graph.FormatJSON, format)
+
+	format, err = graph.ParseFormat("DOT")
+	require.NoError(t, err)
+	assert.Equal(t, graph.FormatDOT, format)
+
+	_, err = graph.ParseFormat("svg")
+	require.Error(t, err)
+}
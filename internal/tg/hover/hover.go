@@ -3,10 +3,13 @@ package hover
 
 import (
 	"strings"
+	"terragrunt-ls/internal/ast"
 	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/schema"
 	"terragrunt-ls/internal/tg/store"
 	"terragrunt-ls/internal/tg/text"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"go.lsp.dev/protocol"
 )
 
@@ -15,6 +18,43 @@ const (
 	// This means that a hover is happening on top of a local variable.
 	HoverContextLocal = "local"
 
+	// HoverContextIncludeAttribute is the context for a hover on a local or
+	// input of an included file.
+	// This means that a hover is happening on top of an
+	// `include.<label>.locals.<name>` or `include.<label>.inputs.<name>`
+	// reference, and the target is `<label>.<attr>.<name>`.
+	HoverContextIncludeAttribute = "include_attribute"
+
+	// HoverContextDependencyOutput is the context for a hover on an output
+	// of a dependency.
+	// This means that a hover is happening on top of a
+	// `dependency.<name>.outputs.<output>` reference, and the target is
+	// `<name>.<output>`.
+	HoverContextDependencyOutput = "dependency_output"
+
+	// HoverContextInclude is the context for a hover on an include.
+	// This means that a hover is happening on top of an `include.<label>`
+	// reference, or on the type or label of an `include` block, and the
+	// target is the label.
+	HoverContextInclude = "include"
+
+	// HoverContextInput is the context for a hover on an input.
+	// This means that a hover is happening on top of a key of the `inputs`
+	// attribute, and the target is the key.
+	HoverContextInput = "input"
+
+	// HoverContextFunction is the context for a hover on a function.
+	// This means that a hover is happening on top of the name of a function
+	// call, and the target is the name of the function.
+	HoverContextFunction = "function"
+
+	// HoverContextSchema is the context for a hover on the name of a block
+	// or attribute of the configuration.
+	// This means that a hover is happening on top of a block type or an
+	// attribute name, and the target is the path of the element in the
+	// schema, such as `terraform.extra_arguments.commands`.
+	HoverContextSchema = "schema"
+
 	// HoverContextNull is the context for a null hover.
 	// This means that a hover is happening on top of nothing useful.
 	HoverContextNull = "null"
@@ -34,7 +74,98 @@ func GetHoverTargetWithContext(l logger.Logger, store store.Store, position prot
 
 	splitExpression := strings.Split(word, ".")
 
-	const localPartsLen = 2
+	const (
+		localPartsLen            = 2
+		includeAttributePartsLen = 4
+		dependencyOutputPartsLen = 4
+		includePartsLen          = 2
+	)
+
+	if len(splitExpression) >= includeAttributePartsLen &&
+		splitExpression[0] == "include" &&
+		(splitExpression[2] == "locals" || splitExpression[2] == "inputs") {
+		l.Debug(
+			"Found include attribute",
+			"line", position.Line,
+			"character", position.Character,
+			"include", splitExpression[1],
+			"attribute", splitExpression[2],
+			"name", splitExpression[3],
+		)
+
+		return strings.Join(splitExpression[1:includeAttributePartsLen], "."), HoverContextIncludeAttribute
+	}
+
+	if len(splitExpression) >= dependencyOutputPartsLen &&
+		splitExpression[0] == "dependency" &&
+		splitExpression[2] == "outputs" {
+		l.Debug(
+			"Found dependency output",
+			"line", position.Line,
+			"character", position.Character,
+			"dependency", splitExpression[1],
+			"output", splitExpression[3],
+		)
+
+		return splitExpression[1] + "." + splitExpression[3], HoverContextDependencyOutput
+	}
+
+	if len(splitExpression) == includePartsLen &&
+		splitExpression[0] == "include" &&
+		splitExpression[1] != "" {
+		l.Debug(
+			"Found include",
+			"line", position.Line,
+			"character", position.Character,
+			"include", splitExpression[1],
+		)
+
+		return splitExpression[1], HoverContextInclude
+	}
+
+	if len(splitExpression) == 1 && text.IsCursorCall(store.Document, position) {
+		l.Debug(
+			"Found function call",
+			"line", position.Line,
+			"character", position.Character,
+			"function", word,
+		)
+
+		return word, HoverContextFunction
+	}
+
+	if label, ok := includeBlockLabel(store, position); ok {
+		l.Debug(
+			"Found include block",
+			"line", position.Line,
+			"character", position.Character,
+			"include", label,
+		)
+
+		return label, HoverContextInclude
+	}
+
+	if key, ok := inputKey(store, position); ok {
+		l.Debug(
+			"Found input",
+			"line", position.Line,
+			"character", position.Character,
+			"input", key,
+		)
+
+		return key, HoverContextInput
+	}
+
+	if path, ok := schemaPath(store, position); ok {
+		l.Debug(
+			"Found schema element",
+			"line", position.Line,
+			"character", position.Character,
+			"path", path,
+		)
+
+		return strings.Join(path, "."), HoverContextSchema
+	}
 
 	if len(splitExpression) != localPartsLen {
 		l.Debug(
@@ -60,3 +191,78 @@ func GetHoverTargetWithContext(l logger.Logger, store store.Store, position prot
 
 	return word, HoverContextNull
 }
+
+// includeBlockLabel returns the label of the `include` block whose type or
+// label is at position.
+func includeBlockLabel(store store.Store, position protocol.Position) (string, bool) {
+	body, ok := hclBody(store)
+	if !ok {
+		return "", false
+	}
+
+	pos := ast.ToHCLPos(position)
+
+	for _, block := range body.Blocks {
+		if block.Type != "include" || len(block.Labels) == 0 {
+			continue
+		}
+
+		if ast.RangeContains(block.TypeRange, pos) || ast.RangeContains(block.LabelRanges[0], pos) {
+			return block.Labels[0], true
+		}
+	}
+
+	return "", false
+}
+
+// inputKey returns the key of the `inputs` attribute at position.
+func inputKey(store store.Store, position protocol.Position) (string, bool) {
+	body, ok := hclBody(store)
+	if !ok {
+		return "", false
+	}
+
+	attr, ok := body.Attributes["inputs"]
+	if !ok {
+		return "", false
+	}
+
+	object, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return "", false
+	}
+
+	pos := ast.ToHCLPos(position)
+
+	for _, item := range object.Items {
+		if !ast.RangeContains(item.KeyExpr.Range(), pos) {
+			continue
+		}
+
+		return ast.ObjectKeyName(item.KeyExpr)
+	}
+
+	return "", false
+}
+
+// schemaPath returns the path of the block or attribute whose name is at
+// position.
+func schemaPath(store store.Store, position protocol.Position) ([]string, bool) {
+	body, ok := hclBody(store)
+	if !ok {
+		return nil, false
+	}
+
+	return schema.PathAt(body, ast.ToHCLPos(position))
+}
+
+// hclBody returns the body of the AST of store.
+func hclBody(store store.Store) (*hclsyntax.Body, bool) {
+	if store.AST == nil || store.AST.HCLFile == nil {
+		return nil, false
+	}
+
+	body, ok := store.AST.HCLFile.Body.(*hclsyntax.Body)
+
+	return body, ok
+}
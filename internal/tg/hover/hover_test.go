@@ -1,12 +1,14 @@
 package hover_test
 
 import (
+	"terragrunt-ls/internal/ast"
 	"terragrunt-ls/internal/testutils"
 	"terragrunt-ls/internal/tg/hover"
 	"terragrunt-ls/internal/tg/store"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.lsp.dev/protocol"
 )
 
@@ -33,6 +35,69 @@ func TestGetHoverTargetWithContext(t *testing.T) {
 			expectedTarget:  "var",
 			expectedContext: "local",
 		},
+		{
+			name:            "include local",
+			store:           store.Store{Document: "include.root.locals.region"},
+			position:        protocol.Position{Line: 0, Character: 22},
+			expectedTarget:  "root.locals.region",
+			expectedContext: "include_attribute",
+		},
+		{
+			name:            "include input attribute",
+			store:           store.Store{Document: "include.root.inputs.tags.env"},
+			position:        protocol.Position{Line: 0, Character: 22},
+			expectedTarget:  "root.inputs.tags",
+			expectedContext: "include_attribute",
+		},
+		{
+			name:            "dependency output",
+			store:           store.Store{Document: "vpc_id = dependency.vpc.outputs.vpc_id"},
+			position:        protocol.Position{Line: 0, Character: 35},
+			expectedTarget:  "vpc.vpc_id",
+			expectedContext: "dependency_output",
+		},
+		{
+			name:            "nested dependency output",
+			store:           store.Store{Document: "dependency.vpc.outputs.subnets.private"},
+			position:        protocol.Position{Line: 0, Character: 12},
+			expectedTarget:  "vpc.subnets",
+			expectedContext: "dependency_output",
+		},
+		{
+			name:            "dependency outputs",
+			store:           store.Store{Document: "dependency.vpc.outputs"},
+			position:        protocol.Position{Line: 0, Character: 12},
+			expectedTarget:  "dependency.vpc.outputs",
+			expectedContext: "null",
+		},
+		{
+			name:            "function call",
+			store:           store.Store{Document: `path = find_in_parent_folders("root.hcl")`},
+			position:        protocol.Position{Line: 0, Character: 12},
+			expectedTarget:  "find_in_parent_folders",
+			expectedContext: "function",
+		},
+		{
+			name:            "word that is not called",
+			store:           store.Store{Document: "length = 3"},
+			position:        protocol.Position{Line: 0, Character: 2},
+			expectedTarget:  "length",
+			expectedContext: "null",
+		},
+		{
+			name:            "include",
+			store:           store.Store{Document: "path = include.root"},
+			position:        protocol.Position{Line: 0, Character: 16},
+			expectedTarget:  "root",
+			expectedContext: "include",
+		},
+		{
+			name:            "other include attribute",
+			store:           store.Store{Document: "include.root.path.value"},
+			position:        protocol.Position{Line: 0, Character: 22},
+			expectedTarget:  "include.root.path.value",
+			expectedContext: "null",
+		},
 	}
 
 	for _, tt := range tc {
@@ -48,3 +113,109 @@ func TestGetHoverTargetWithContext(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHoverTargetWithContext_Schema(t *testing.T) {
+	t.Parallel()
+
+	document := `terraform {
+  before_hook "fmt" {
+    commands = ["plan"]
+  }
+}
+
+locals {
+  region = "us-east-1"
+}
+
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+inputs = {
+  "cidr" = "10.0.0.0/16"
+  tags = {
+    team = "platform"
+  }
+}
+`
+
+	indexedAST, err := ast.ParseHCLFile("terragrunt.hcl", []byte(document))
+	require.NoError(t, err)
+
+	st := store.Store{Document: document, AST: indexedAST}
+
+	tc := []struct {
+		name            string
+		expectedTarget  string
+		expectedContext string
+		position        protocol.Position
+	}{
+		{
+			name:            "block type",
+			position:        protocol.Position{Line: 0, Character: 2},
+			expectedTarget:  "terraform",
+			expectedContext: "schema",
+		},
+		{
+			name:            "nested block type",
+			position:        protocol.Position{Line: 1, Character: 4},
+			expectedTarget:  "terraform.before_hook",
+			expectedContext: "schema",
+		},
+		{
+			name:            "attribute of nested block",
+			position:        protocol.Position{Line: 2, Character: 6},
+			expectedTarget:  "terraform.before_hook.commands",
+			expectedContext: "schema",
+		},
+		{
+			name:            "local",
+			position:        protocol.Position{Line: 7, Character: 3},
+			expectedTarget:  "locals.region",
+			expectedContext: "schema",
+		},
+		{
+			name:            "include block type",
+			position:        protocol.Position{Line: 10, Character: 3},
+			expectedTarget:  "root",
+			expectedContext: "include",
+		},
+		{
+			name:            "include block label",
+			position:        protocol.Position{Line: 10, Character: 10},
+			expectedTarget:  "root",
+			expectedContext: "include",
+		},
+		{
+			name:            "input",
+			position:        protocol.Position{Line: 15, Character: 4},
+			expectedTarget:  "cidr",
+			expectedContext: "input",
+		},
+		{
+			name:            "nested key of input",
+			position:        protocol.Position{Line: 17, Character: 5},
+			expectedTarget:  "team",
+			expectedContext: "null",
+		},
+		{
+			name:            "label",
+			position:        protocol.Position{Line: 1, Character: 17},
+			expectedTarget:  "fmt",
+			expectedContext: "null",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := testutils.NewTestLogger(t)
+
+			target, context := hover.GetHoverTargetWithContext(l, st, tt.position)
+
+			assert.Equal(t, tt.expectedTarget, target)
+			assert.Equal(t, tt.expectedContext, context)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package tg
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/rename"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// includedAttribute is the declaration of a local or input in an included
+// file.
+type includedAttribute struct {
+	// Path is the absolute path of the included file.
+	Path string
+	// AST is the AST of the included file.
+	AST *ast.IndexedAST
+	// NameRange is the range of the name of the local or input.
+	NameRange hcl.Range
+	// DeclRange is the range of the whole declaration.
+	DeclRange hcl.Range
+}
+
+// Source returns the text of the declaration.
+func (a includedAttribute) Source() string {
+	if a.AST == nil || a.AST.HCLFile == nil {
+		return ""
+	}
+
+	return string(a.DeclRange.SliceBytes(a.AST.HCLFile.Bytes))
+}
+
+// findIncludedAttribute resolves a `<label>.<attr>.<name>` target of the
+// document to the declaration in the included file.
+func (s *State) findIncludedAttribute(l logger.Logger, st store.Store, docURI protocol.DocumentURI, target string) (includedAttribute, bool) {
+	const targetParts = 3
+
+	parts := strings.SplitN(target, ".", targetParts)
+	if len(parts) != targetParts {
+		return includedAttribute{}, false
+	}
+
+	label, attr, name := parts[0], parts[1], parts[2]
+
+	path, ok := s.includedPath(st, docURI, label)
+	if !ok {
+		l.Debug(
+			"Include not found",
+			"uri", docURI,
+			"include", label,
+		)
+
+		return includedAttribute{}, false
+	}
+
+	includedAST, ok := s.loadAST(s.Index(docURI.Filename()), path)
+	if !ok {
+		l.Debug(
+			"Included file could not be loaded",
+			"path", path,
+		)
+
+		return includedAttribute{}, false
+	}
+
+	nameRange, declRange, ok := includedAST.FindIncludedAttribute(attr, name)
+	if !ok {
+		l.Debug(
+			"Included attribute not found",
+			"path", path,
+			"attribute", attr,
+			"name", name,
+		)
+
+		return includedAttribute{}, false
+	}
+
+	return includedAttribute{
+		Path:      path,
+		AST:       includedAST,
+		NameRange: nameRange,
+		DeclRange: declRange,
+	}, true
+}
+
+// includedPath returns the absolute path of the file included by the
+// `include` block of the document with the given label.
+//
+// The includes processed by Terragrunt are preferred, and the paths are
+// resolved from the AST when the document could not be parsed by Terragrunt.
+func (s *State) includedPath(st store.Store, docURI protocol.DocumentURI, label string) (string, bool) {
+	dir := filepath.Dir(docURI.Filename())
+
+	if st.Cfg != nil {
+		for _, include := range st.Cfg.ProcessedIncludes {
+			if include.Name != label {
+				continue
+			}
+
+			if filepath.IsAbs(include.Path) {
+				return filepath.Clean(include.Path), true
+			}
+
+			return filepath.Join(dir, include.Path), true
+		}
+	}
+
+	for _, include := range workspace.NewFile(docURI.Filename(), st.AST).Includes {
+		if include.Name == label {
+			return include.Path, true
+		}
+	}
+
+	return "", false
+}
+
+// loadAST returns the AST of the file at path, preferring the open document,
+// then index, then the file on disk.
+func (s *State) loadAST(index *workspace.Index, path string) (*ast.IndexedAST, bool) {
+	if st, ok := s.lookupOpen(uri.File(path)); ok && st.AST != nil {
+		return st.AST, true
+	}
+
+	if file, ok := index.File(path); ok && file.AST != nil {
+		return file.AST, true
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	// Ignore errors, as the AST of files with syntax errors is still usable
+	indexedAST, _ := ast.ParseHCLFile(path, content)
+	if indexedAST == nil {
+		return nil, false
+	}
+
+	return indexedAST, true
+}
+
+// includeReferences returns the references of the symbol at position that
+// cross include boundaries, and whether there is such a symbol:
+//
+//   - For an `include.<label>.<attr>.<name>` reference, the declaration in
+//     the included file and the references in every file that includes it.
+//   - For a local of the document, the references to it in the files that
+//     include the document.
+func (s *State) includeReferences(l logger.Logger, st store.Store, docURI protocol.DocumentURI, position protocol.Position, includeDeclaration bool) ([]protocol.Location, bool) {
+	node := st.AST.FindNodeAt(ast.ToHCLPos(position))
+	if node == nil {
+		return nil, false
+	}
+
+	if expr, ok := node.Node.(*hclsyntax.ScopeTraversalExpr); ok {
+		if ref, ok := ast.GetIncludeReference(expr); ok {
+			target := strings.Join([]string{ref.Label, ref.Attr, ref.Name}, ".")
+
+			attr, ok := s.findIncludedAttribute(l, st, docURI, target)
+			if !ok {
+				return nil, false
+			}
+
+			var locations []protocol.Location
+
+			if includeDeclaration {
+				locations = append(locations, protocol.Location{
+					URI:   uri.File(attr.Path),
+					Range: ast.FromHCLRange(attr.NameRange),
+				})
+			}
+
+			current := includerReference{path: docURI.Filename(), refs: workspace.NewFile(docURI.Filename(), st.AST).IncludeReferences, labels: []string{ref.Label}}
+
+			return append(locations, s.includerReferences(s.Index(docURI.Filename()), attr.Path, ref.Attr, ref.Name, current)...), true
+		}
+	}
+
+	target := rename.GetRenameTarget(l, st, position)
+	if target.Context != rename.RenameContextLocal {
+		return nil, false
+	}
+
+	if _, ok := st.AST.Locals[target.Name]; !ok {
+		return nil, false
+	}
+
+	return s.includerReferences(s.Index(docURI.Filename()), docURI.Filename(), ast.IncludeAttrLocals, target.Name, includerReference{}), true
+}
+
+// includerReference is a file that includes another, with its include
+// references and the labels of the `include` blocks that point at it.
+type includerReference struct {
+	path   string
+	refs   []ast.IncludeReference
+	labels []string
+}
+
+// includerReferences returns the `include.<label>.<attr>.<name>` references
+// to the file at path in the files of index that include it, and in current
+// when it is set. Open documents are searched in their latest version.
+func (s *State) includerReferences(index *workspace.Index, path, attr, name string, current includerReference) []protocol.Location {
+	includers := map[string]includerReference{}
+
+	for _, file := range index.Includers(path) {
+		if st, ok := s.lookupOpen(uri.File(file.Path)); ok && st.AST != nil {
+			file = workspace.NewFile(file.Path, st.AST)
+		}
+
+		var labels []string
+
+		for _, include := range file.Includes {
+			if include.Path == path {
+				labels = append(labels, include.Name)
+			}
+		}
+
+		includers[file.Path] = includerReference{path: file.Path, refs: file.IncludeReferences, labels: labels}
+	}
+
+	if current.path != "" {
+		includers[current.path] = current
+	}
+
+	paths := make([]string, 0, len(includers))
+	for includerPath := range includers {
+		paths = append(paths, includerPath)
+	}
+
+	slices.Sort(paths)
+
+	var locations []protocol.Location
+
+	for _, includerPath := range paths {
+		includer := includers[includerPath]
+
+		var ranges []protocol.Range
+
+		for _, ref := range includer.refs {
+			if ref.Attr == attr && ref.Name == name && slices.Contains(includer.labels, ref.Label) {
+				ranges = append(ranges, ast.FromHCLRange(ref.NameRange))
+			}
+		}
+
+		// HCL walks attributes in map iteration order, which is non-deterministic.
+		slices.SortFunc(ranges, func(a, b protocol.Range) int {
+			if a.Start.Line != b.Start.Line {
+				return int(a.Start.Line) - int(b.Start.Line)
+			}
+
+			return int(a.Start.Character) - int(b.Start.Character)
+		})
+
+		for _, r := range ranges {
+			locations = append(locations, protocol.Location{URI: uri.File(includerPath), Range: r})
+		}
+	}
+
+	return locations
+}
@@ -0,0 +1,177 @@
+package tg
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/store"
+
+	"github.com/gruntwork-io/terragrunt/pkg/config"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"go.lsp.dev/protocol"
+)
+
+// includeHover returns the hover of the include of the document called
+// label: the path it resolves to, how it is merged, whether it is exposed,
+// and the blocks and attributes the included file contributes.
+func (s *State) includeHover(l logger.Logger, st store.Store, docURI protocol.DocumentURI, label string) (string, bool) {
+	path, ok := s.includedPath(st, docURI, label)
+	if !ok {
+		l.Debug(
+			"Include not found",
+			"uri", docURI,
+			"include", label,
+		)
+
+		return "", false
+	}
+
+	mergeStrategy, expose := includeSettings(st, label)
+
+	sections := []string{
+		"`path`: `" + path + "`",
+		"`merge_strategy`: `" + mergeStrategy + "`",
+		"`expose`: `" + expose + "`",
+	}
+
+	if mergeStrategy == string(config.NoMerge) {
+		return strings.Join(append(sections, "Contributes nothing, as `merge_strategy` is `no_merge`."), "\n\n"), true
+	}
+
+	includedAST, ok := s.loadAST(s.Index(docURI.Filename()), path)
+	if !ok || includedAST.HCLFile == nil {
+		l.Debug(
+			"Included file could not be loaded",
+			"path", path,
+		)
+
+		return strings.Join(sections, "\n\n"), true
+	}
+
+	contributed := contributedElements(includedAST)
+	if len(contributed) == 0 {
+		return strings.Join(append(sections, "Contributes nothing."), "\n\n"), true
+	}
+
+	return strings.Join(append(sections, "Contributes:\n\n- "+strings.Join(contributed, "\n- ")), "\n\n"), true
+}
+
+// includeSettings returns the `merge_strategy` and `expose` settings of the
+// include called label, from the includes processed by Terragrunt, or from
+// the `include` block when the document could not be parsed by Terragrunt.
+func includeSettings(st store.Store, label string) (string, string) {
+	mergeStrategy, expose := string(config.ShallowMerge), "false"
+
+	if st.Cfg != nil {
+		for _, include := range st.Cfg.ProcessedIncludes {
+			if include.Name != label {
+				continue
+			}
+
+			if include.MergeStrategy != nil {
+				mergeStrategy = *include.MergeStrategy
+			}
+
+			return mergeStrategy, strconv.FormatBool(include.GetExpose())
+		}
+	}
+
+	if st.AST == nil || st.AST.HCLFile == nil {
+		return mergeStrategy, expose
+	}
+
+	body, ok := st.AST.HCLFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return mergeStrategy, expose
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "include" || len(block.Labels) == 0 || block.Labels[0] != label {
+			continue
+		}
+
+		if value, ok := attributeValue(block.Body, "merge_strategy", st.AST.HCLFile.Bytes); ok {
+			mergeStrategy = value
+		}
+
+		if value, ok := attributeValue(block.Body, "expose", st.AST.HCLFile.Bytes); ok {
+			expose = value
+		}
+	}
+
+	return mergeStrategy, expose
+}
+
+// attributeValue returns the value of the attribute called name of body, when
+// it is a string or bool literal, and its source from src otherwise.
+func attributeValue(body *hclsyntax.Body, name string, src []byte) (string, bool) {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return "", false
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() {
+		return string(attr.Expr.Range().SliceBytes(src)), true
+	}
+
+	switch value.Type() {
+	case cty.String:
+		return value.AsString(), true
+	case cty.Bool:
+		return strconv.FormatBool(value.True()), true
+	}
+
+	return string(attr.Expr.Range().SliceBytes(src)), true
+}
+
+// contributedElements returns the top-level blocks and attributes of the
+// included file that are merged into the files that include it, in the order
+// they appear. `locals` and `include` blocks are never merged.
+func contributedElements(includedAST *ast.IndexedAST) []string {
+	body, ok := includedAST.HCLFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+
+	type element struct {
+		name  string
+		start hcl.Pos
+	}
+
+	var elements []element
+
+	for _, block := range body.Blocks {
+		if block.Type == "locals" || block.Type == "include" {
+			continue
+		}
+
+		name := "`" + block.Type
+
+		for _, label := range block.Labels {
+			name += " \"" + label + "\""
+		}
+
+		elements = append(elements, element{name: name + "`", start: block.TypeRange.Start})
+	}
+
+	for _, attr := range body.Attributes {
+		elements = append(elements, element{name: "`" + attr.Name + "`", start: attr.NameRange.Start})
+	}
+
+	slices.SortFunc(elements, func(a, b element) int {
+		return cmp.Compare(a.start.Byte, b.start.Byte)
+	})
+
+	names := make([]string, 0, len(elements))
+	for _, e := range elements {
+		names = append(names, e.name)
+	}
+
+	return names
+}
@@ -0,0 +1,100 @@
+package tg
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/module"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/text"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"go.lsp.dev/protocol"
+)
+
+// inputVariableHover returns the hover of the input called name of the
+// document: the declaration of the variable it is passed to, when the
+// `terraform` source of the unit is a local module.
+func (s *State) inputVariableHover(l logger.Logger, st store.Store, docURI protocol.DocumentURI, name string) (string, bool) {
+	source := moduleDir(st, docURI.Filename())
+	if source == "" {
+		l.Debug(
+			"No local module",
+			"uri", docURI,
+		)
+
+		return "", false
+	}
+
+	decl, ok := module.FindVariable(source, name)
+	if !ok {
+		l.Debug(
+			"Variable not found",
+			"module", source,
+			"variable", name,
+		)
+
+		return "", false
+	}
+
+	sections := []string{"Variable declared in `" + relativePath(docURI, decl.Path) + "`:"}
+
+	if decl.Description != "" {
+		sections = append(sections, decl.Description)
+	}
+
+	sections = append(sections, variableSummary(decl), text.WrapAsHCLCodeFence(strings.TrimSpace(decl.Source)))
+
+	return strings.Join(sections, "\n\n"), true
+}
+
+// moduleDir returns the directory of the local module of the unit at
+// filename, or "" when its `terraform` source is not a local path.
+//
+// The source resolved by Terragrunt is preferred, as it follows locals and
+// `terraform` blocks inherited from includes. The AST is only used when the
+// unit could not be parsed.
+func moduleDir(st store.Store, filename string) string {
+	if st.Cfg == nil {
+		return workspace.NewFile(filename, st.AST).Source
+	}
+
+	if st.Cfg.Terraform == nil || st.Cfg.Terraform.Source == nil || !workspace.IsLocalSource(*st.Cfg.Terraform.Source) {
+		return ""
+	}
+
+	source := *st.Cfg.Terraform.Source
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(filepath.Dir(filename), source)
+	}
+
+	return filepath.Clean(source)
+}
+
+// variableSummary lists the type, default and sensitivity of the variable
+// declared by decl. Defaults that span several lines are left to the
+// declaration.
+func variableSummary(decl module.Declaration) string {
+	typ := decl.Type
+	if typ == "" {
+		typ = "any"
+	}
+
+	var defaultValue string
+
+	switch {
+	case decl.Default == "":
+		defaultValue = "none, the input is required"
+	case strings.Contains(decl.Default, "\n"):
+		defaultValue = "see the declaration"
+	default:
+		defaultValue = "`" + decl.Default + "`"
+	}
+
+	return strings.Join([]string{
+		"- `type`: `" + typ + "`",
+		"- `default`: " + defaultValue,
+		"- `sensitive`: `" + strconv.FormatBool(decl.Sensitive) + "`",
+	}, "\n")
+}
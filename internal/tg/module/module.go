@@ -0,0 +1,161 @@
+// Package module provides the logic for reading the declarations of a local
+// OpenTofu/Terraform module, such as the outputs a unit exposes to the units
+// that depend on it, and the variables its inputs are passed to.
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Declaration is a block of a module that declares one of its outputs or
+// variables.
+type Declaration struct {
+	// Path is the absolute path of the file the block is in.
+	Path string
+
+	// Source is the source of the whole block.
+	Source string
+
+	// Description is the value of the `description` attribute of the block,
+	// when it is a string literal.
+	Description string
+
+	// Type is the source of the `type` attribute of a variable.
+	Type string
+
+	// Default is the source of the `default` attribute of a variable.
+	Default string
+
+	// Sensitive is the value of the `sensitive` attribute of the block, when
+	// it is a bool literal.
+	Sensitive bool
+}
+
+// FindOutput returns the declaration of the output called name of the module
+// in dir.
+func FindOutput(dir, name string) (Declaration, bool) {
+	return find(dir, "output", name, "outputs.tf")
+}
+
+// FindVariable returns the declaration of the variable called name of the
+// module in dir.
+func FindVariable(dir, name string) (Declaration, bool) {
+	return find(dir, "variable", name, "variables.tf")
+}
+
+// find returns the first block of type blockType labelled name in the `.tf`
+// files of dir. The file conventionally holding such blocks is searched
+// first, then the others in lexical order.
+func find(dir, blockType, name, conventional string) (Declaration, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Declaration{}, false
+	}
+
+	var files []string
+
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && filepath.Ext(entry.Name()) == ".tf" {
+			files = append(files, entry.Name())
+		}
+	}
+
+	// Sort the conventional file first, keeping the others in lexical order.
+	slices.SortStableFunc(files, func(a, b string) int {
+		switch {
+		case a == conventional:
+			return -1
+		case b == conventional:
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		// Ignore errors, as blocks before a syntax error are still usable
+		hclFile, _ := hclsyntax.ParseConfig(content, path, hcl.Pos{Line: 1, Column: 1})
+		if hclFile == nil {
+			continue
+		}
+
+		body, ok := hclFile.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != blockType || len(block.Labels) == 0 || block.Labels[0] != name {
+				continue
+			}
+
+			return Declaration{
+				Path:        path,
+				Source:      string(block.Range().SliceBytes(content)),
+				Description: stringAttribute(block.Body, "description"),
+				Type:        attributeSource(block.Body, "type", content),
+				Default:     attributeSource(block.Body, "default", content),
+				Sensitive:   boolAttribute(block.Body, "sensitive"),
+			}, true
+		}
+	}
+
+	return Declaration{}, false
+}
+
+// stringAttribute returns the value of the attribute called name of body,
+// when it is a string literal.
+func stringAttribute(body *hclsyntax.Body, name string) string {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return ""
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || value.Type() != cty.String {
+		return ""
+	}
+
+	return value.AsString()
+}
+
+// boolAttribute returns the value of the attribute called name of body, when
+// it is a bool literal.
+func boolAttribute(body *hclsyntax.Body, name string) bool {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return false
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || value.Type() != cty.Bool {
+		return false
+	}
+
+	return value.True()
+}
+
+// attributeSource returns the source of the expression of the attribute
+// called name of body.
+func attributeSource(body *hclsyntax.Body, name string, src []byte) string {
+	attr, ok := body.Attributes[name]
+	if !ok {
+		return ""
+	}
+
+	return string(attr.Expr.Range().SliceBytes(src))
+}
@@ -0,0 +1,169 @@
+package module_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg/module"
+)
+
+func TestFindOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := testutils.CreateFile(dir, "main.tf", `output "vpc_id" {
+  value = "shadowed"
+}
+
+output "cidr" {
+  value = aws_vpc.this.cidr_block
+}
+`)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(dir, "outputs.tf", `output "vpc_id" {
+  description = "The ID of the VPC"
+  value       = aws_vpc.this.id
+}
+`)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(dir, "README.md", `output "readme" {}`)
+	require.NoError(t, err)
+
+	tc := []struct {
+		name     string
+		output   string
+		expected module.Declaration
+		found    bool
+	}{
+		{
+			name:   "outputs.tf first",
+			output: "vpc_id",
+			expected: module.Declaration{
+				Path:        filepath.Join(dir, "outputs.tf"),
+				Source:      "output \"vpc_id\" {\n  description = \"The ID of the VPC\"\n  value       = aws_vpc.this.id\n}",
+				Description: "The ID of the VPC",
+			},
+			found: true,
+		},
+		{
+			name:   "other file",
+			output: "cidr",
+			expected: module.Declaration{
+				Path:   filepath.Join(dir, "main.tf"),
+				Source: "output \"cidr\" {\n  value = aws_vpc.this.cidr_block\n}",
+			},
+			found: true,
+		},
+		{
+			name:   "not a .tf file",
+			output: "readme",
+		},
+		{
+			name:   "missing",
+			output: "subnets",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			decl, found := module.FindOutput(dir, tt.output)
+
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, decl)
+		})
+	}
+}
+
+func TestFindVariable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := testutils.CreateFile(dir, "variables.tf", `variable "cidr" {
+  type        = string
+  description = "The CIDR block of the VPC"
+  default     = "10.0.0.0/16"
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+`)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(dir, "main.tf", `variable "tags" {
+  type = map(string)
+  default = {
+    team = "platform"
+  }
+}
+`)
+	require.NoError(t, err)
+
+	tc := []struct {
+		name     string
+		variable string
+		expected module.Declaration
+		found    bool
+	}{
+		{
+			name:     "with default",
+			variable: "cidr",
+			expected: module.Declaration{
+				Path:        filepath.Join(dir, "variables.tf"),
+				Source:      "variable \"cidr\" {\n  type        = string\n  description = \"The CIDR block of the VPC\"\n  default     = \"10.0.0.0/16\"\n}",
+				Description: "The CIDR block of the VPC",
+				Type:        "string",
+				Default:     `"10.0.0.0/16"`,
+			},
+			found: true,
+		},
+		{
+			name:     "sensitive",
+			variable: "password",
+			expected: module.Declaration{
+				Path:      filepath.Join(dir, "variables.tf"),
+				Source:    "variable \"password\" {\n  type      = string\n  sensitive = true\n}",
+				Type:      "string",
+				Sensitive: true,
+			},
+			found: true,
+		},
+		{
+			name:     "other file",
+			variable: "tags",
+			expected: module.Declaration{
+				Path:    filepath.Join(dir, "main.tf"),
+				Source:  "variable \"tags\" {\n  type = map(string)\n  default = {\n    team = \"platform\"\n  }\n}",
+				Type:    "map(string)",
+				Default: "{\n    team = \"platform\"\n  }",
+			},
+			found: true,
+		},
+		{
+			name:     "missing",
+			variable: "region",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			decl, found := module.FindVariable(dir, tt.variable)
+
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, decl)
+		})
+	}
+}
@@ -82,7 +82,7 @@ func GetRenameTarget(l logger.Logger, st store.Store, position protocol.Position
 			return null
 		}
 
-		if !rangeContainsPosition(attr.NameRange, position) {
+		if !ast.RangeContains(attr.NameRange, ast.ToHCLPos(position)) {
 			return null
 		}
 
@@ -125,7 +125,7 @@ func traversalTarget(expr *hclsyntax.ScopeTraversalExpr, position protocol.Posit
 		Start:    rootStep.SrcRange.Start,
 		End:      attrStep.SrcRange.End,
 	}
-	if !rangeContainsPosition(firstTwo, position) {
+	if !ast.RangeContains(firstTwo, ast.ToHCLPos(position)) {
 		return null
 	}
 
@@ -194,24 +194,3 @@ func definitionOccurrences(target RenameTarget, file string, iast *ast.IndexedAS
 		IsDefinition: true,
 	}}
 }
-
-// rangeContainsPosition reports whether p (LSP coordinates) is inside r (HCL
-// coordinates). The end of an HCL range is exclusive.
-func rangeContainsPosition(r hcl.Range, p protocol.Position) bool {
-	line := int(p.Line) + 1
-	col := int(p.Character) + 1
-
-	if line < r.Start.Line || line > r.End.Line {
-		return false
-	}
-
-	if line == r.Start.Line && col < r.Start.Column {
-		return false
-	}
-
-	if line == r.End.Line && col >= r.End.Column {
-		return false
-	}
-
-	return true
-}
@@ -0,0 +1,88 @@
+// Package schema provides the schema of the blocks and attributes of
+// Terragrunt configuration files, with their documentation.
+package schema
+
+import (
+	"terragrunt-ls/internal/ast"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Element is a block or an attribute of a configuration file.
+type Element struct {
+	// Name is the type of the block, or the name of the attribute.
+	Name string
+
+	// Description is what the element is used for, in Markdown.
+	Description string
+
+	// Children are the attributes and blocks nested in a block.
+	Children []Element
+
+	// Block is true for blocks, and false for attributes.
+	Block bool
+}
+
+// Documentation returns the Markdown documentation of e.
+func (e Element) Documentation() string {
+	return "# " + e.Name + "\n" + e.Description
+}
+
+// Find returns the element nested in e at path, such as
+// `terraform`, `extra_arguments`, `commands`.
+func (e Element) Find(path ...string) (Element, bool) {
+	current := e
+
+	for _, name := range path {
+		found := false
+
+		for _, child := range current.Children {
+			if child.Name == name {
+				current, found = child, true
+
+				break
+			}
+		}
+
+		if !found {
+			return Element{}, false
+		}
+	}
+
+	return current, true
+}
+
+// PathAt returns the path of the element whose name is at pos in body: the
+// types of the blocks it is nested in, then its own type or name. Positions
+// in labels, values and bodies have no path.
+func PathAt(body *hclsyntax.Body, pos hcl.Pos) ([]string, bool) {
+	var path []string
+
+	for body != nil {
+		var next *hclsyntax.Body
+
+		for _, attr := range body.Attributes {
+			if ast.RangeContains(attr.NameRange, pos) {
+				return append(path, attr.Name), true
+			}
+		}
+
+		for _, block := range body.Blocks {
+			if ast.RangeContains(block.TypeRange, pos) {
+				return append(path, block.Type), true
+			}
+
+			if block.Body != nil && ast.RangeContains(block.Body.SrcRange, pos) {
+				path = append(path, block.Type)
+				next = block.Body
+
+				break
+			}
+		}
+
+		body = next
+	}
+
+	return nil, false
+}
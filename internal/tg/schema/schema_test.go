@@ -0,0 +1,188 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/tg/schema"
+)
+
+func TestElement_Find(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		root     schema.Element
+		path     []string
+		expected string
+		block    bool
+		found    bool
+	}{
+		{
+			name:     "top-level block",
+			root:     schema.Unit,
+			path:     []string{"remote_state"},
+			expected: "remote_state",
+			block:    true,
+			found:    true,
+		},
+		{
+			name:     "top-level attribute",
+			root:     schema.Unit,
+			path:     []string{"iam_role"},
+			expected: "iam_role",
+			found:    true,
+		},
+		{
+			name:     "nested block",
+			root:     schema.Unit,
+			path:     []string{"terraform", "before_hook"},
+			expected: "before_hook",
+			block:    true,
+			found:    true,
+		},
+		{
+			name:     "attribute of nested block",
+			root:     schema.Unit,
+			path:     []string{"terraform", "extra_arguments", "commands"},
+			expected: "commands",
+			found:    true,
+		},
+		{
+			name:     "attribute of dependency",
+			root:     schema.Unit,
+			path:     []string{"dependency", "mock_outputs_allowed_terraform_commands"},
+			expected: "mock_outputs_allowed_terraform_commands",
+			found:    true,
+		},
+		{
+			name:     "error hook",
+			root:     schema.Unit,
+			path:     []string{"terraform", "error_hook", "on_errors"},
+			expected: "on_errors",
+			found:    true,
+		},
+		{
+			name: "conditions are not attributes of error hooks",
+			root: schema.Unit,
+			path: []string{"terraform", "error_hook", "run_on_error"},
+		},
+		{
+			name:     "stack unit",
+			root:     schema.Stack,
+			path:     []string{"unit", "values"},
+			expected: "values",
+			found:    true,
+		},
+		{
+			name: "local",
+			root: schema.Unit,
+			path: []string{"locals", "region"},
+		},
+		{
+			name: "unit block in unit",
+			root: schema.Unit,
+			path: []string{"unit"},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			element, found := tt.root.Find(tt.path...)
+
+			require.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, element.Name)
+			assert.Equal(t, tt.block, element.Block)
+		})
+	}
+}
+
+func TestPathAt(t *testing.T) {
+	t.Parallel()
+
+	document := `terraform {
+  source = "../modules//vpc"
+
+  extra_arguments "vars" {
+    commands = ["plan"]
+  }
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`
+
+	file, diags := hclsyntax.ParseConfig([]byte(document), "terragrunt.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors())
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	require.True(t, ok)
+
+	tc := []struct {
+		name     string
+		expected []string
+		pos      hcl.Pos
+		found    bool
+	}{
+		{
+			name:     "block type",
+			pos:      hcl.Pos{Line: 1, Column: 3},
+			expected: []string{"terraform"},
+			found:    true,
+		},
+		{
+			name:     "attribute of block",
+			pos:      hcl.Pos{Line: 2, Column: 3},
+			expected: []string{"terraform", "source"},
+			found:    true,
+		},
+		{
+			name:     "nested block type",
+			pos:      hcl.Pos{Line: 4, Column: 5},
+			expected: []string{"terraform", "extra_arguments"},
+			found:    true,
+		},
+		{
+			name:     "attribute of nested block",
+			pos:      hcl.Pos{Line: 5, Column: 8},
+			expected: []string{"terraform", "extra_arguments", "commands"},
+			found:    true,
+		},
+		{
+			name:     "end of attribute name",
+			pos:      hcl.Pos{Line: 10, Column: 13},
+			expected: []string{"dependency", "config_path"},
+			found:    true,
+		},
+		{
+			name: "attribute value",
+			pos:  hcl.Pos{Line: 2, Column: 16},
+		},
+		{
+			name: "block label",
+			pos:  hcl.Pos{Line: 9, Column: 14},
+		},
+		{
+			name: "empty line",
+			pos:  hcl.Pos{Line: 3, Column: 1},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path, found := schema.PathAt(body, tt.pos)
+
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package schema
+
+// Stack is the schema of terragrunt.stack.hcl files.
+var Stack = Element{
+	Block: true,
+	Children: []Element{
+		{
+			Name:        "unit",
+			Description: "The unit block references a Terragrunt unit to include in this stack.",
+			Block:       true,
+			Children:    stackEntryElements,
+		},
+		{
+			Name:        "stack",
+			Description: "The stack block references another Terragrunt stack to nest within this stack.",
+			Block:       true,
+			Children:    stackEntryElements,
+		},
+		{
+			Name:        "locals",
+			Description: "The locals block defines aliases for expressions reusable within the stack file.",
+			Block:       true,
+		},
+	},
+}
+
+// stackEntryElements are the attributes of `unit` and `stack` blocks.
+var stackEntryElements = []Element{
+	{
+		Name:        "source",
+		Description: "The source attribute is the location of the configuration to generate, as a local path or a go-getter URL.",
+	},
+	{
+		Name:        "path",
+		Description: "The path attribute is the directory to generate the configuration in, relative to the `.terragrunt-stack` directory.",
+	},
+	{
+		Name:        "values",
+		Description: "The values attribute is a map of values written to the `terragrunt.values.hcl` file of the generated configuration, available there as `values`.",
+	},
+	{
+		Name:        "no_dot_terragrunt_stack",
+		Description: "The no_dot_terragrunt_stack attribute is used to generate the configuration next to the stack file, instead of in the `.terragrunt-stack` directory.",
+	},
+	{
+		Name:        "no_validation",
+		Description: "The no_validation attribute is used to skip checking that the generated configuration is valid.",
+	},
+}
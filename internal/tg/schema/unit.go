@@ -0,0 +1,395 @@
+package schema
+
+import "slices"
+
+// Unit is the schema of terragrunt.hcl files.
+var Unit = Element{
+	Block: true,
+	Children: []Element{
+		{
+			Name:        "dependency",
+			Description: "The dependency block is used to configure unit dependencies.\nEach dependency block exposes outputs of the dependency unit as variables you can reference in dependent unit configuration.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "config_path",
+					Description: "The config_path attribute is the path to the unit this unit depends on, relative to the directory of this unit.",
+				},
+				{
+					Name:        "enabled",
+					Description: "The enabled attribute is used to disable the dependency, which is enabled by default.",
+				},
+				{
+					Name:        "skip_outputs",
+					Description: "The skip_outputs attribute is used to skip reading the outputs of the dependency, and use mock_outputs instead.",
+				},
+				{
+					Name:        "mock_outputs",
+					Description: "The mock_outputs attribute is a map of outputs to use in place of the outputs of the dependency, when it has not been applied yet.",
+				},
+				{
+					Name:        "mock_outputs_allowed_terraform_commands",
+					Description: "The mock_outputs_allowed_terraform_commands attribute is a list of the OpenTofu/Terraform commands mock_outputs can be used for, such as `validate` and `plan`. Mocks are used for every command when it is not set.",
+				},
+				{
+					Name:        "mock_outputs_merge_strategy_with_state",
+					Description: "The mock_outputs_merge_strategy_with_state attribute is used to merge mock_outputs with the outputs of the dependency: `no_merge` (the default), `shallow` or `deep_map_only`.",
+				},
+				{
+					Name:        "mock_outputs_merge_with_state",
+					Description: "The mock_outputs_merge_with_state attribute is used to merge mock_outputs with the outputs of the dependency. It is deprecated in favor of mock_outputs_merge_strategy_with_state.",
+				},
+			},
+		},
+		{
+			Name:        "inputs",
+			Description: "The inputs attribute is a map that is used to specify the input variables and their values to pass in to OpenTofu/Terraform.",
+		},
+		{
+			Name:        "locals",
+			Description: "The locals block is used to define aliases for Terragrunt expressions that can be referenced elsewhere in configuration.",
+			Block:       true,
+		},
+		{
+			Name:        "feature",
+			Description: "The feature block is used to configure feature flags in HCL for a specific Terragrunt unit.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "default",
+					Description: "The default attribute is the value of the feature flag when it is not set with `--feature`.",
+				},
+			},
+		},
+		{
+			Name:        "terraform",
+			Description: "The terraform block is used to configure how Terragrunt will interact with OpenTofu/Terraform.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "source",
+					Description: "The source attribute is the location of the OpenTofu/Terraform module of the unit, as a local path or a go-getter URL.",
+				},
+				{
+					Name:        "include_in_copy",
+					Description: "The include_in_copy attribute is a list of glob patterns of files to copy into the working directory, that are skipped by default, such as hidden files.",
+				},
+				{
+					Name:        "exclude_from_copy",
+					Description: "The exclude_from_copy attribute is a list of glob patterns of files not to copy into the working directory.",
+				},
+				{
+					Name:        "copy_terraform_lock_file",
+					Description: "The copy_terraform_lock_file attribute is used to stop copying the `.terraform.lock.hcl` file generated in the working directory back to the unit directory.",
+				},
+				{
+					Name:        "extra_arguments",
+					Description: "The extra_arguments block is used to pass extra arguments and environment variables to OpenTofu/Terraform commands.",
+					Block:       true,
+					Children: []Element{
+						{
+							Name:        "commands",
+							Description: "The commands attribute is the list of OpenTofu/Terraform commands the arguments are passed to.",
+						},
+						{
+							Name:        "arguments",
+							Description: "The arguments attribute is the list of arguments to pass to the commands.",
+						},
+						{
+							Name:        "required_var_files",
+							Description: "The required_var_files attribute is a list of var files to pass to the commands with `-var-file`, which must exist.",
+						},
+						{
+							Name:        "optional_var_files",
+							Description: "The optional_var_files attribute is a list of var files to pass to the commands with `-var-file`, when they exist.",
+						},
+						{
+							Name:        "env_vars",
+							Description: "The env_vars attribute is a map of environment variables to set when running the commands.",
+						},
+					},
+				},
+				{
+					Name:        "before_hook",
+					Description: "The before_hook block is used to run a command before OpenTofu/Terraform commands.",
+					Block:       true,
+					Children:    slices.Concat(hookElements, conditionalHookElements),
+				},
+				{
+					Name:        "after_hook",
+					Description: "The after_hook block is used to run a command after OpenTofu/Terraform commands.",
+					Block:       true,
+					Children:    slices.Concat(hookElements, conditionalHookElements),
+				},
+				{
+					Name:        "error_hook",
+					Description: "The error_hook block is used to run a command when OpenTofu/Terraform commands fail with an error matching on_errors.",
+					Block:       true,
+					Children: slices.Concat([]Element{
+						{
+							Name:        "on_errors",
+							Description: "The on_errors attribute is a list of regular expressions matched against the error, to decide whether to run the hook.",
+						},
+					}, hookElements),
+				},
+			},
+		},
+		{
+			Name:        "remote_state",
+			Description: "The remote_state block is used to configure how Terragrunt will set up remote state configuration.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "backend",
+					Description: "The backend attribute is the type of the backend, such as `s3` or `gcs`.",
+				},
+				{
+					Name:        "config",
+					Description: "The config attribute is a map of the configuration of the backend.",
+				},
+				{
+					Name:        "generate",
+					Description: "The generate attribute is used to generate the backend configuration into a file, with a `path` and an `if_exists` strategy.",
+				},
+				{
+					Name:        "disable_init",
+					Description: "The disable_init attribute is used to skip creating the backend resources, such as the S3 bucket, when they do not exist.",
+				},
+				{
+					Name:        "disable_dependency_optimization",
+					Description: "The disable_dependency_optimization attribute is used to read the outputs of dependencies with `output` instead of fetching their state directly.",
+				},
+				{
+					Name:        "encryption",
+					Description: "The encryption attribute is a map of the state encryption configuration of OpenTofu.",
+				},
+			},
+		},
+		{
+			Name:        "include",
+			Description: "The include block is used to specify the inclusion of partial Terragrunt configuration.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "path",
+					Description: "The path attribute is the path of the configuration to include.",
+				},
+				{
+					Name:        "expose",
+					Description: "The expose attribute is used to make the configuration of the included file available as `include.<label>`.",
+				},
+				{
+					Name:        "merge_strategy",
+					Description: "The merge_strategy attribute is how the included configuration is merged with this one: `shallow` (the default), `deep` or `no_merge`.",
+				},
+			},
+		},
+		{
+			Name:        "dependencies",
+			Description: "The dependencies block is used to enumerate all the Terragrunt units that need to be applied before this unit.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "paths",
+					Description: "The paths attribute is the list of the paths to the units this unit depends on.",
+				},
+			},
+		},
+		{
+			Name:        "generate",
+			Description: "The generate block can be used to arbitrarily generate a file in the terragrunt working directory.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "path",
+					Description: "The path attribute is the path of the generated file, relative to the working directory.",
+				},
+				{
+					Name:        "if_exists",
+					Description: "The if_exists attribute is what to do when the file already exists: `overwrite`, `overwrite_terragrunt`, `skip` or `error`.",
+				},
+				{
+					Name:        "if_disabled",
+					Description: "The if_disabled attribute is what to do with the file when the block is disabled: `remove`, `remove_terragrunt` or `skip`.",
+				},
+				{
+					Name:        "contents",
+					Description: "The contents attribute is the content of the generated file.",
+				},
+				{
+					Name:        "comment_prefix",
+					Description: "The comment_prefix attribute is the prefix of the signature comment written at the top of the file, which is `# ` by default.",
+				},
+				{
+					Name:        "disable_signature",
+					Description: "The disable_signature attribute is used to skip writing the signature comment at the top of the file.",
+				},
+				{
+					Name:        "disable",
+					Description: "The disable attribute is used to disable the block.",
+				},
+			},
+		},
+		{
+			Name:        "engine",
+			Description: "The engine block is used to configure Terragrunt engine configuration.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "source",
+					Description: "The source attribute is the location of the engine, as a local path or a URL.",
+				},
+				{
+					Name:        "version",
+					Description: "The version attribute is the version of the engine to download.",
+				},
+				{
+					Name:        "type",
+					Description: "The type attribute is the type of the engine, which is `rpc`.",
+				},
+				{
+					Name:        "meta",
+					Description: "The meta attribute is a map of settings passed to the engine.",
+				},
+			},
+		},
+		{
+			Name:        "exclude",
+			Description: "The exclude block provides configuration options to dynamically determine when and how a unit is excluded from the run queue.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "if",
+					Description: "The if attribute is the condition under which the unit is excluded.",
+				},
+				{
+					Name:        "actions",
+					Description: "The actions attribute is the list of commands the unit is excluded from, such as `plan`, or `all`.",
+				},
+				{
+					Name:        "exclude_dependencies",
+					Description: "The exclude_dependencies attribute is used to exclude the dependencies of the unit too.",
+				},
+				{
+					Name:        "no_run",
+					Description: "The no_run attribute is used to prevent the unit from running even when it is run on its own.",
+				},
+			},
+		},
+		{
+			Name:        "errors",
+			Description: "The errors block is used to configure how Terragrunt handles errors, by retrying or ignoring them.",
+			Block:       true,
+			Children: []Element{
+				{
+					Name:        "retry",
+					Description: "The retry block is used to retry the commands that fail with errors matching retryable_errors.",
+					Block:       true,
+					Children: []Element{
+						{
+							Name:        "retryable_errors",
+							Description: "The retryable_errors attribute is a list of regular expressions of the errors to retry.",
+						},
+						{
+							Name:        "max_attempts",
+							Description: "The max_attempts attribute is the maximum number of attempts.",
+						},
+						{
+							Name:        "sleep_interval_sec",
+							Description: "The sleep_interval_sec attribute is the number of seconds to wait between attempts.",
+						},
+					},
+				},
+				{
+					Name:        "ignore",
+					Description: "The ignore block is used to ignore the errors matching ignorable_errors, so that the run succeeds.",
+					Block:       true,
+					Children: []Element{
+						{
+							Name:        "ignorable_errors",
+							Description: "The ignorable_errors attribute is a list of regular expressions of the errors to ignore. Patterns prefixed with `!` are never ignored.",
+						},
+						{
+							Name:        "message",
+							Description: "The message attribute is the message to log when an error is ignored.",
+						},
+						{
+							Name:        "signals",
+							Description: "The signals attribute is a map of values written to `error-signals.json` when an error is ignored.",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "download_dir",
+			Description: "The download_dir string option can be used to override the default download directory (which is .terragrunt-cache by default).",
+		},
+		{
+			Name:        "prevent_destroy",
+			Description: "The prevent_destroy boolean attribute prevents the unit from being destroyed.",
+		},
+		{
+			Name:        "iam_role",
+			Description: "The iam_role attribute is used to specify an IAM role that Terragrunt should assume prior to running OpenTofu/Terraform.",
+		},
+		{
+			Name:        "iam_assume_role_duration",
+			Description: "The iam_assume_role_duration attribute is used to specify the STS session duration, in seconds.",
+		},
+		{
+			Name:        "iam_assume_role_session_name",
+			Description: "The iam_assume_role_session_name attribute is used to specify the STS session name.",
+		},
+		{
+			Name:        "iam_web_identity_token",
+			Description: "The iam_web_identity_token attribute is used along with iam_role to assume a role using the AssumeRoleWithWebIdentity API.",
+		},
+		{
+			Name:        "terraform_binary",
+			Description: "The terraform_binary attribute is used to override the binary Terragrunt uses during runs (which is tofu by default).",
+		},
+		{
+			Name:        "terraform_version_constraint",
+			Description: "The terraform_version_constraint attribute is used to override the default minimum supported version of OpenTofu/Terraform.",
+		},
+		{
+			Name:        "terragrunt_version_constraint",
+			Description: "The terragrunt_version_constraint attribute is used to specify which versions of the Terragrunt CLI can be used.",
+		},
+	},
+}
+
+// hookElements are the attributes of `before_hook`, `after_hook` and
+// `error_hook` blocks.
+var hookElements = []Element{
+	{
+		Name:        "commands",
+		Description: "The commands attribute is the list of OpenTofu/Terraform commands that trigger the hook.",
+	},
+	{
+		Name:        "execute",
+		Description: "The execute attribute is the command to run, as a list of the program and its arguments.",
+	},
+	{
+		Name:        "working_dir",
+		Description: "The working_dir attribute is the directory to run the command in, which is the working directory by default.",
+	},
+	{
+		Name:        "suppress_stdout",
+		Description: "The suppress_stdout attribute is used to hide the standard output of the command.",
+	},
+}
+
+// conditionalHookElements are the attributes of `before_hook` and
+// `after_hook` blocks that decide whether they run.
+var conditionalHookElements = []Element{
+	{
+		Name:        "run_on_error",
+		Description: "The run_on_error attribute is used to run the hook even when a previous hook or command failed.",
+	},
+	{
+		Name:        "if",
+		Description: "The if attribute is the condition under which the hook runs.",
+	},
+}
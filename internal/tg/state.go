@@ -5,16 +5,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"terragrunt-ls/internal/ast"
 	"terragrunt-ls/internal/logger"
 	"terragrunt-ls/internal/lsp"
 	"terragrunt-ls/internal/tg/completion"
 	"terragrunt-ls/internal/tg/definition"
+	"terragrunt-ls/internal/tg/functions"
 	"terragrunt-ls/internal/tg/hover"
 	"terragrunt-ls/internal/tg/references"
 	"terragrunt-ls/internal/tg/rename"
+	"terragrunt-ls/internal/tg/schema"
 	"terragrunt-ls/internal/tg/store"
 	"terragrunt-ls/internal/tg/text"
+	"terragrunt-ls/internal/tg/workspace"
 
 	"github.com/gruntwork-io/terragrunt/pkg/config"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -26,10 +30,49 @@ import (
 type State struct {
 	// Map of file names to Terragrunt configs
 	Configs map[string]store.Store
+
+	// folders maps the roots of the workspace folders to the index of their
+	// Terragrunt files, including the ones that are not open.
+	folders map[string]*workspace.Index
+
+	// index holds the Terragrunt files outside every workspace folder.
+	index *workspace.Index
+
+	// mu guards Configs and folders, as requests are served concurrently
+	// with document updates.
+	mu sync.RWMutex
+}
+
+func NewState() *State {
+	return &State{
+		Configs: map[string]store.Store{},
+		folders: map[string]*workspace.Index{},
+		index:   workspace.NewIndex(),
+	}
+}
+
+// lookup returns the store for the given document, if it is known.
+func (s *State) lookup(docURI protocol.DocumentURI) (store.Store, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, ok := s.Configs[docURI.Filename()]
+
+	return st, ok
 }
 
-func NewState() State {
-	return State{Configs: map[string]store.Store{}}
+// lookupOpen returns the store for the given document, if it is open in the
+// editor.
+//
+// Cross-file lookups use it instead of lookup, as the index is kept up to
+// date with the disk, while documents loaded from disk are only snapshots.
+func (s *State) lookupOpen(docURI protocol.DocumentURI) (store.Store, bool) {
+	st, ok := s.lookup(docURI)
+	if !ok || st.Owner != store.OwnerEditor {
+		return store.Store{}, false
+	}
+
+	return st, true
 }
 
 func (s *State) OpenDocument(ctx context.Context, l logger.Logger, docURI protocol.DocumentURI, text string) []protocol.Diagnostic {
@@ -52,7 +95,121 @@ func (s *State) UpdateDocument(ctx context.Context, l logger.Logger, docURI prot
 	return s.updateState(ctx, l, docURI, text)
 }
 
+// CloseDocument hands a document closed in the editor back to the disk.
+//
+// If the file still exists, its store is downgraded to the content on disk,
+// which is only parsed into an AST. Otherwise, the store is evicted.
+func (s *State) CloseDocument(l logger.Logger, docURI protocol.DocumentURI) {
+	st, ok := loadDiskStore(l, docURI)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !ok {
+		delete(s.Configs, docURI.Filename())
+
+		return
+	}
+
+	s.Configs[docURI.Filename()] = st
+}
+
+// RefreshDiskDocument reloads the store of a document loaded from disk after
+// its file changed, or evicts it if the file is gone. Documents owned by the
+// editor are left alone, as their content does not come from the disk.
+func (s *State) RefreshDiskDocument(l logger.Logger, docURI protocol.DocumentURI) {
+	if st, ok := s.lookup(docURI); !ok || st.Owner != store.OwnerDisk {
+		return
+	}
+
+	st, ok := loadDiskStore(l, docURI)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The document may have been opened in the editor meanwhile.
+	if current, known := s.Configs[docURI.Filename()]; !known || current.Owner != store.OwnerDisk {
+		return
+	}
+
+	if !ok {
+		delete(s.Configs, docURI.Filename())
+
+		return
+	}
+
+	s.Configs[docURI.Filename()] = st
+}
+
+// loadDiskStore reads the content of a document from disk into a store that
+// is only parsed into an AST, and reports whether the file could be read.
+func loadDiskStore(l logger.Logger, docURI protocol.DocumentURI) (store.Store, bool) {
+	filename := docURI.Filename()
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		l.Debug(
+			"Evicting document missing from disk",
+			"uri", docURI,
+			"error", err,
+		)
+
+		return store.Store{}, false
+	}
+
+	l.Debug(
+		"Loading document from disk",
+		"uri", docURI,
+	)
+
+	// Ignore errors, as no diagnostics are published for documents loaded from disk
+	indexedAST, _ := ast.ParseHCLFile(filename, content)
+
+	return store.Store{
+		AST:      indexedAST,
+		CfgAsCty: cty.NilVal,
+		Document: string(content),
+		FileType: DetectFileType(filename),
+		Owner:    store.OwnerDisk,
+	}, true
+}
+
+// WorkspaceFile returns the last parsed version of a document as an indexed
+// file, and whether the document is known.
+func (s *State) WorkspaceFile(docURI protocol.DocumentURI) (*workspace.File, bool) {
+	st, ok := s.lookup(docURI)
+	if !ok {
+		return nil, false
+	}
+
+	return workspace.NewFile(docURI.Filename(), st.AST), true
+}
+
+// Owner returns the owner of the document, and whether the document is known.
+func (s *State) Owner(docURI protocol.DocumentURI) (store.Owner, bool) {
+	st, ok := s.lookup(docURI)
+
+	return st.Owner, ok
+}
+
+// Document returns the current text of the document, and whether the document is known.
+func (s *State) Document(docURI protocol.DocumentURI) (string, bool) {
+	st, ok := s.lookup(docURI)
+
+	return st.Document, ok
+}
+
 func (s *State) updateState(ctx context.Context, l logger.Logger, docURI protocol.DocumentURI, text string) []protocol.Diagnostic {
+	st, diags := s.ParseDocument(ctx, l, docURI, text)
+
+	s.StoreParsedDocument(docURI, st)
+
+	return diags
+}
+
+// ParseDocument parses the text of a document without storing the result, so
+// that the caller can decide whether the result is still wanted.
+func (s *State) ParseDocument(ctx context.Context, l logger.Logger, docURI protocol.DocumentURI, text string) (store.Store, []protocol.Diagnostic) {
 	filename := docURI.Filename()
 	fileType := DetectFileType(filename)
 
@@ -110,13 +267,48 @@ func (s *State) updateState(ctx context.Context, l logger.Logger, docURI protoco
 		diags = []protocol.Diagnostic{}
 	}
 
-	s.Configs[filename] = st
+	return st, diags
+}
 
-	return diags
+// StoreDocument replaces the store of a document with one returned by ParseDocument.
+//
+// Parsing happens outside the lock, so that requests keep being served
+// from the previous store until the new one is ready.
+func (s *State) StoreDocument(docURI protocol.DocumentURI, st store.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Configs[docURI.Filename()] = st
 }
 
-func (s *State) Hover(l logger.Logger, id int, docURI protocol.DocumentURI, position protocol.Position) lsp.HoverResponse {
-	st, ok := s.Configs[docURI.Filename()]
+// StoreParsedDocument stores a parse of a document being edited, like
+// StoreDocument, but keeps the configuration of the previous store when
+// Terragrunt could not parse the new text.
+//
+// This way, a typo does not take away hover and cross-file data until the
+// document parses again.
+func (s *State) StoreParsedDocument(docURI protocol.DocumentURI, st store.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filename := docURI.Filename()
+
+	if prev, ok := s.Configs[filename]; ok && prev.Owner == store.OwnerEditor && prev.FileType == st.FileType {
+		if st.Cfg == nil {
+			st.Cfg = prev.Cfg
+			st.CfgAsCty = prev.CfgAsCty
+		}
+
+		if st.StackCfg == nil {
+			st.StackCfg = prev.StackCfg
+		}
+	}
+
+	s.Configs[filename] = st
+}
+
+func (s *State) Hover(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI, position protocol.Position) lsp.HoverResponse {
+	st, ok := s.lookup(docURI)
 	if !ok {
 		return newEmptyHoverResponse(id)
 	}
@@ -127,30 +319,25 @@ func (s *State) Hover(l logger.Logger, id int, docURI protocol.DocumentURI, posi
 		"position", position,
 	)
 
-	if st.FileType != store.FileTypeUnit {
-		return newEmptyHoverResponse(id)
-	}
-
 	l.Debug(
 		"Config",
 		"uri", docURI,
 		"config", st.Cfg,
 	)
 
-	word, context := hover.GetHoverTargetWithContext(l, st, position)
+	word, hoverCtx := hover.GetHoverTargetWithContext(l, st, position)
 
 	l.Debug(
 		"Hovering with context",
 		"word", word,
-		"context", context,
+		"context", hoverCtx,
 	)
 
-	if word == "" {
+	if word == "" || !hoverSupported(st.FileType, hoverCtx) {
 		return newEmptyHoverResponse(id)
 	}
 
-	//nolint:gocritic
-	switch context {
+	switch hoverCtx {
 	case hover.HoverContextLocal:
 		if st.Cfg == nil {
 			return newEmptyHoverResponse(id)
@@ -174,7 +361,7 @@ func (s *State) Hover(l logger.Logger, id int, docURI protocol.DocumentURI, posi
 		return lsp.HoverResponse{
 			Response: lsp.Response{
 				RPC: lsp.RPCVersion,
-				ID:  &id,
+				ID:  id,
 			},
 			Result: lsp.HoverResult{
 				Contents: protocol.MarkupContent{
@@ -183,22 +370,176 @@ func (s *State) Hover(l logger.Logger, id int, docURI protocol.DocumentURI, posi
 				},
 			},
 		}
+
+	case hover.HoverContextIncludeAttribute:
+		attr, ok := s.findIncludedAttribute(l, st, docURI, word)
+		if !ok {
+			return newEmptyHoverResponse(id)
+		}
+
+		return lsp.HoverResponse{
+			Response: lsp.Response{
+				RPC: lsp.RPCVersion,
+				ID:  id,
+			},
+			Result: lsp.HoverResult{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: text.WrapAsHCLCodeFence(strings.TrimSpace(attr.Source())) + "\n\nDefined in `" + relativePath(docURI, attr.Path) + "`",
+				},
+			},
+		}
+
+	case hover.HoverContextDependencyOutput:
+		value, ok := s.dependencyOutputHover(l, st, docURI, word)
+		if !ok {
+			return newEmptyHoverResponse(id)
+		}
+
+		return lsp.HoverResponse{
+			Response: lsp.Response{
+				RPC: lsp.RPCVersion,
+				ID:  id,
+			},
+			Result: lsp.HoverResult{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: value,
+				},
+			},
+		}
+
+	case hover.HoverContextInclude:
+		value, ok := s.includeHover(l, st, docURI, word)
+		if !ok {
+			return newEmptyHoverResponse(id)
+		}
+
+		return lsp.HoverResponse{
+			Response: lsp.Response{
+				RPC: lsp.RPCVersion,
+				ID:  id,
+			},
+			Result: lsp.HoverResult{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: value,
+				},
+			},
+		}
+
+	case hover.HoverContextInput:
+		value, ok := s.inputVariableHover(l, st, docURI, word)
+		if !ok {
+			return newEmptyHoverResponse(id)
+		}
+
+		return lsp.HoverResponse{
+			Response: lsp.Response{
+				RPC: lsp.RPCVersion,
+				ID:  id,
+			},
+			Result: lsp.HoverResult{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: value,
+				},
+			},
+		}
+
+	case hover.HoverContextFunction:
+		fn, ok := functions.Lookup(word)
+		if !ok {
+			return newEmptyHoverResponse(id)
+		}
+
+		return lsp.HoverResponse{
+			Response: lsp.Response{
+				RPC: lsp.RPCVersion,
+				ID:  id,
+			},
+			Result: lsp.HoverResult{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: fn.Documentation(),
+				},
+			},
+		}
+
+	case hover.HoverContextSchema:
+		element, ok := schemaOf(st.FileType).Find(strings.Split(word, ".")...)
+		if !ok {
+			return newEmptyHoverResponse(id)
+		}
+
+		return lsp.HoverResponse{
+			Response: lsp.Response{
+				RPC: lsp.RPCVersion,
+				ID:  id,
+			},
+			Result: lsp.HoverResult{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: element.Documentation(),
+				},
+			},
+		}
 	}
 
 	return newEmptyHoverResponse(id)
 }
 
-func newEmptyHoverResponse(id int) lsp.HoverResponse {
+// hoverSupported reports whether hovers in hoverCtx are supported in files
+// of fileType.
+//
+// Functions and blocks are used in shared files such as `root.hcl` and in
+// stack files as much as in units, while the other contexts rely on the
+// configuration of a unit.
+func hoverSupported(fileType store.FileType, hoverCtx string) bool {
+	switch fileType {
+	case store.FileTypeUnit:
+		return true
+	case store.FileTypeUnknown, store.FileTypeStack:
+		return hoverCtx == hover.HoverContextFunction || hoverCtx == hover.HoverContextSchema
+	case store.FileTypeValues:
+		return false
+	}
+
+	return false
+}
+
+// schemaOf returns the schema of files of fileType. Shared files such as
+// `root.hcl` are included by units, so they follow the schema of units.
+func schemaOf(fileType store.FileType) schema.Element {
+	if fileType == store.FileTypeStack {
+		return schema.Stack
+	}
+
+	return schema.Unit
+}
+
+// relativePath returns path relative to the directory of the document, or
+// path itself when there is no relative path.
+func relativePath(docURI protocol.DocumentURI, path string) string {
+	rel, err := filepath.Rel(filepath.Dir(docURI.Filename()), path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+func newEmptyHoverResponse(id lsp.ID) lsp.HoverResponse {
 	return lsp.HoverResponse{
 		Response: lsp.Response{
 			RPC: lsp.RPCVersion,
-			ID:  &id,
+			ID:  id,
 		},
 	}
 }
 
-func (s *State) Definition(l logger.Logger, id int, docURI protocol.DocumentURI, position protocol.Position) lsp.DefinitionResponse {
-	st, ok := s.Configs[docURI.Filename()]
+func (s *State) Definition(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI, position protocol.Position) lsp.DefinitionResponse {
+	st, ok := s.lookup(docURI)
 	if !ok {
 		return newEmptyDefinitionResponse(id, docURI, position)
 	}
@@ -229,11 +570,22 @@ func (s *State) Definition(l logger.Logger, id int, docURI protocol.DocumentURI,
 	case definition.DefinitionContextLocal:
 		if loc, ok := s.findLocalDefinition(l, st, docURI, position, target); ok {
 			return lsp.DefinitionResponse{
-				Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+				Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 				Result:   loc,
 			}
 		}
 
+	case definition.DefinitionContextIncludeAttribute:
+		if attr, ok := s.findIncludedAttribute(l, st, docURI, target); ok {
+			return lsp.DefinitionResponse{
+				Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
+				Result: protocol.Location{
+					URI:   uri.File(attr.Path),
+					Range: ast.FromHCLRange(attr.NameRange),
+				},
+			}
+		}
+
 	case definition.DefinitionContextInclude:
 		l.Debug(
 			"Store content",
@@ -266,7 +618,7 @@ func (s *State) Definition(l logger.Logger, id int, docURI protocol.DocumentURI,
 				return lsp.DefinitionResponse{
 					Response: lsp.Response{
 						RPC: lsp.RPCVersion,
-						ID:  &id,
+						ID:  id,
 					},
 					Result: protocol.Location{
 						URI: defURI,
@@ -332,7 +684,7 @@ func (s *State) Definition(l logger.Logger, id int, docURI protocol.DocumentURI,
 				return lsp.DefinitionResponse{
 					Response: lsp.Response{
 						RPC: lsp.RPCVersion,
-						ID:  &id,
+						ID:  id,
 					},
 					Result: protocol.Location{
 						URI: defURI,
@@ -377,11 +729,11 @@ func (s *State) findLocalDefinition(l logger.Logger, st store.Store, docURI prot
 	return protocol.Location{}, false
 }
 
-func newEmptyDefinitionResponse(id int, docURI protocol.DocumentURI, position protocol.Position) lsp.DefinitionResponse {
+func newEmptyDefinitionResponse(id lsp.ID, docURI protocol.DocumentURI, position protocol.Position) lsp.DefinitionResponse {
 	return lsp.DefinitionResponse{
 		Response: lsp.Response{
 			RPC: lsp.RPCVersion,
-			ID:  &id,
+			ID:  id,
 		},
 		Result: protocol.Location{
 			URI: docURI,
@@ -393,11 +745,11 @@ func newEmptyDefinitionResponse(id int, docURI protocol.DocumentURI, position pr
 	}
 }
 
-func (s *State) TextDocumentCompletion(l logger.Logger, id int, docURI protocol.DocumentURI, position protocol.Position) lsp.CompletionResponse {
-	st, ok := s.Configs[docURI.Filename()]
+func (s *State) TextDocumentCompletion(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI, position protocol.Position) lsp.CompletionResponse {
+	st, ok := s.lookup(docURI)
 	if !ok {
 		return lsp.CompletionResponse{
-			Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+			Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 			Result:   []protocol.CompletionItem{},
 		}
 	}
@@ -407,7 +759,7 @@ func (s *State) TextDocumentCompletion(l logger.Logger, id int, docURI protocol.
 	response := lsp.CompletionResponse{
 		Response: lsp.Response{
 			RPC: "2.0",
-			ID:  &id,
+			ID:  id,
 		},
 		Result: items,
 	}
@@ -415,11 +767,11 @@ func (s *State) TextDocumentCompletion(l logger.Logger, id int, docURI protocol.
 	return response
 }
 
-func (s *State) TextDocumentFormatting(l logger.Logger, id int, docURI protocol.DocumentURI) lsp.FormatResponse {
-	st, ok := s.Configs[docURI.Filename()]
+func (s *State) TextDocumentFormatting(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI) lsp.FormatResponse {
+	st, ok := s.lookup(docURI)
 	if !ok {
 		return lsp.FormatResponse{
-			Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+			Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 			Result:   []protocol.TextEdit{},
 		}
 	}
@@ -434,7 +786,7 @@ func (s *State) TextDocumentFormatting(l logger.Logger, id int, docURI protocol.
 	return lsp.FormatResponse{
 		Response: lsp.Response{
 			RPC: lsp.RPCVersion,
-			ID:  &id,
+			ID:  id,
 		},
 		Result: []protocol.TextEdit{
 			{
@@ -451,13 +803,13 @@ func (s *State) TextDocumentFormatting(l logger.Logger, id int, docURI protocol.
 	}
 }
 
-func (s *State) PrepareRename(l logger.Logger, id int, docURI protocol.DocumentURI, position protocol.Position) lsp.PrepareRenameResponse {
+func (s *State) PrepareRename(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI, position protocol.Position) lsp.PrepareRenameResponse {
 	empty := lsp.PrepareRenameResponse{
-		Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 		Result:   nil,
 	}
 
-	st, ok := s.Configs[docURI.Filename()]
+	st, ok := s.lookup(docURI)
 	if !ok || !canRename(st) {
 		return empty
 	}
@@ -468,7 +820,7 @@ func (s *State) PrepareRename(l logger.Logger, id int, docURI protocol.DocumentU
 	}
 
 	return lsp.PrepareRenameResponse{
-		Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 		Result: &lsp.PrepareRenameResult{
 			Range:       target.IdentRange,
 			Placeholder: target.Name,
@@ -476,13 +828,13 @@ func (s *State) PrepareRename(l logger.Logger, id int, docURI protocol.DocumentU
 	}
 }
 
-func (s *State) TextDocumentRename(l logger.Logger, id int, docURI protocol.DocumentURI, position protocol.Position, newName string) lsp.RenameResponse {
+func (s *State) TextDocumentRename(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI, position protocol.Position, newName string) lsp.RenameResponse {
 	empty := lsp.RenameResponse{
-		Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 		Result:   nil,
 	}
 
-	st, ok := s.Configs[docURI.Filename()]
+	st, ok := s.lookup(docURI)
 	if !ok || !canRename(st) {
 		return empty
 	}
@@ -517,7 +869,7 @@ func (s *State) TextDocumentRename(l logger.Logger, id int, docURI protocol.Docu
 	}
 
 	return lsp.RenameResponse{
-		Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 		Result: &protocol.WorkspaceEdit{
 			Changes: changes,
 		},
@@ -535,28 +887,82 @@ func canRename(st store.Store) bool {
 	return st.FileType == store.FileTypeUnit || st.FileType == store.FileTypeUnknown
 }
 
-func (s *State) TextDocumentReferences(l logger.Logger, id int, docURI protocol.DocumentURI, position protocol.Position, includeDeclaration bool) lsp.ReferencesResponse {
+func (s *State) TextDocumentReferences(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI, position protocol.Position, includeDeclaration bool) lsp.ReferencesResponse {
 	empty := lsp.ReferencesResponse{
-		Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 		Result:   nil,
 	}
 
-	st, ok := s.Configs[docURI.Filename()]
+	st, ok := s.lookup(docURI)
 	if !ok || !canRename(st) {
 		return empty
 	}
 
 	locations := references.GetReferences(l, st, position, docURI.Filename(), includeDeclaration)
+
+	// Locals and inputs of included files are referenced across files.
+	if includeLocations, ok := s.includeReferences(l, st, docURI, position, includeDeclaration); ok {
+		locations = append(locations, includeLocations...)
+	}
+
+	// The top of a unit file stands for the unit itself, so its references
+	// are the units that depend on it.
+	if len(locations) == 0 && position.Line == 0 && st.FileType == store.FileTypeUnit {
+		for _, dependent := range s.dependents(docURI) {
+			locations = append(locations, protocol.Location{URI: dependent.URI, Range: dependent.Range})
+		}
+	}
+
 	if len(locations) == 0 {
 		return empty
 	}
 
 	return lsp.ReferencesResponse{
-		Response: lsp.Response{RPC: lsp.RPCVersion, ID: &id},
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
 		Result:   locations,
 	}
 }
 
+// Dependents returns the units of the workspace whose `dependency` or
+// `dependencies` blocks point at the unit.
+func (s *State) Dependents(l logger.Logger, id lsp.ID, docURI protocol.DocumentURI) lsp.DependentsResponse {
+	dependents := s.dependents(docURI)
+
+	l.Debug(
+		"Found dependents",
+		"uri", docURI,
+		"dependents", len(dependents),
+	)
+
+	return lsp.DependentsResponse{
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
+		Result:   dependents,
+	}
+}
+
+// dependents returns a reference for every path of the indexed files that
+// resolves to the configuration of the unit.
+func (s *State) dependents(docURI protocol.DocumentURI) []lsp.Dependent {
+	target := docURI.Filename()
+	dependents := []lsp.Dependent{}
+
+	for _, file := range s.Index(target).Dependents(target) {
+		for _, dep := range file.Dependencies {
+			if dep.Path != target {
+				continue
+			}
+
+			dependents = append(dependents, lsp.Dependent{
+				URI:   uri.File(file.Path),
+				Name:  dep.Name,
+				Range: dep.Range,
+			})
+		}
+	}
+
+	return dependents
+}
+
 func getEndOfDocument(doc string) protocol.Position {
 	lines := strings.Split(doc, "\n")
 
@@ -0,0 +1,69 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+func TestState_DependencyCycles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"app", "db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0755))
+	}
+
+	appText := "dependency \"db\" {\n  config_path = \"../db\"\n}\n"
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "app"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "db"), "terragrunt.hcl", "dependencies {\n  paths = [\"../app\"]\n}\n")
+	require.NoError(t, err)
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	require.NoError(t, s.Index(tmpDir).Scan(t.Context(), l, tmpDir, nil))
+
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+
+	// The unit is checked in its unsaved version, against the workspace.
+	st, _ := s.ParseDocument(t.Context(), l, appURI, appText)
+
+	assert.Equal(t, []protocol.Diagnostic{
+		{
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 1, Character: 16},
+				End:   protocol.Position{Line: 1, Character: 23},
+			},
+			Severity: protocol.DiagnosticSeverityError,
+			Source:   tg.ValidationSource,
+			Message:  "Dependency cycle: app -> db -> app",
+			RelatedInformation: []protocol.DiagnosticRelatedInformation{
+				{
+					Location: protocol.Location{
+						URI: uri.File(filepath.Join(tmpDir, "db", "terragrunt.hcl")),
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 11},
+							End:   protocol.Position{Line: 1, Character: 19},
+						},
+					},
+					Message: "db depends on app",
+				},
+			},
+		},
+	}, s.DependencyCycles(l, appURI, st))
+
+	st, _ = s.ParseDocument(t.Context(), l, appURI, "")
+	assert.Empty(t, s.DependencyCycles(l, appURI, st))
+}
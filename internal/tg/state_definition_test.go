@@ -9,6 +9,7 @@ import (
 	"go.lsp.dev/protocol"
 	"go.lsp.dev/uri"
 
+	"terragrunt-ls/internal/lsp"
 	"terragrunt-ls/internal/testutils"
 	"terragrunt-ls/internal/tg"
 )
@@ -36,7 +37,7 @@ inputs = {
 	s.OpenDocument(t.Context(), l, docURI, content)
 
 	// Cursor on `foo` in `local.foo`.
-	resp := s.Definition(l, 1, docURI, protocol.Position{Line: 5, Character: 14})
+	resp := s.Definition(l, lsp.NewNumberID(1), docURI, protocol.Position{Line: 5, Character: 14})
 
 	assert.Equal(t, docURI, resp.Result.URI)
 	assert.Equal(t, uint32(1), resp.Result.Range.Start.Line)
@@ -63,7 +64,7 @@ func TestState_Definition_LocalReference_NotFound(t *testing.T) {
 	s.OpenDocument(t.Context(), l, docURI, content)
 
 	// Cursor on `nonexistent` — no `locals` block defines it.
-	resp := s.Definition(l, 1, docURI, protocol.Position{Line: 1, Character: 18})
+	resp := s.Definition(l, lsp.NewNumberID(1), docURI, protocol.Position{Line: 1, Character: 18})
 
 	// Empty response points back at the cursor position.
 	assert.Equal(t, docURI, resp.Result.URI)
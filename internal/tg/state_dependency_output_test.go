@@ -0,0 +1,163 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+const dependingApp = `dependency "vpc" {
+  config_path = "../vpc"
+
+  mock_outputs = {
+    vpc_id = "vpc-mock"
+  }
+  mock_outputs_allowed_terraform_commands = ["validate", "plan"]
+}
+
+dependency "db" {
+  config_path = "../db"
+}
+
+inputs = {
+  vpc_id  = dependency.vpc.outputs.vpc_id
+  db_host = dependency.db.outputs.host
+  missing = dependency.cache.outputs.host
+}
+`
+
+func TestState_Hover_DependencyOutput(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"modules/vpc", "live/vpc", "live/db", "live/app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.FromSlash(dir)), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "modules", "vpc"), "outputs.tf", `output "vpc_id" {
+  description = "The ID of the VPC"
+  value       = aws_vpc.this.id
+}
+`)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "live", "vpc"), "terragrunt.hcl", "terraform {\n  source = \"../../modules//vpc\"\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "live", "db"), "terragrunt.hcl", "terraform {\n  source = \"git::https://example.com/modules.git//db\"\n}\n")
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "live", "app", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, dependingApp)
+
+	tc := []struct {
+		name     string
+		expected string
+		position protocol.Position
+	}{
+		{
+			name:     "local module",
+			position: protocol.Position{Line: 14, Character: 38},
+			expected: "`config_path`: `" + filepath.Join(tmpDir, "live", "vpc") + "`\n\n" +
+				"Mock output, used for `validate`, `plan`:\n\n" +
+				"```hcl\nvpc_id = \"vpc-mock\"\n```\n\n" +
+				"Output declared in `" + filepath.Join("..", "..", "modules", "vpc", "outputs.tf") + "`:\n\n" +
+				"The ID of the VPC\n\n" +
+				"```hcl\noutput \"vpc_id\" {\n  description = \"The ID of the VPC\"\n  value       = aws_vpc.this.id\n}\n```",
+		},
+		{
+			name:     "remote module without mocks",
+			position: protocol.Position{Line: 15, Character: 36},
+			expected: "`config_path`: `" + filepath.Join(tmpDir, "live", "db") + "`",
+		},
+		{
+			name:     "unknown dependency",
+			position: protocol.Position{Line: 16, Character: 38},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Hover(l, lsp.NewNumberID(1), appURI, tt.position)
+			assert.Equal(t, tt.expected, resp.Result.Contents.Value)
+		})
+	}
+}
+
+func TestState_Hover_DependencyOutput_ResolvedPaths(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"modules/vpc", "live/vpc", "live/app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.FromSlash(dir)), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "modules", "vpc"), "outputs.tf", "output \"vpc_id\" {\n  value = aws_vpc.this.id\n}\n")
+	require.NoError(t, err)
+
+	// Both the dependency and the module of the unit it points at are built
+	// from locals, which only Terragrunt can resolve. The unit is open, as
+	// only open documents are evaluated.
+	vpc := "locals {\n  modules = \"../../modules\"\n}\n\nterraform {\n  source = \"${local.modules}//vpc\"\n}\n"
+
+	vpcPath, err := testutils.CreateFile(filepath.Join(tmpDir, "live", "vpc"), "terragrunt.hcl", vpc)
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "live", "app", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, uri.File(vpcPath), vpc)
+	s.OpenDocument(t.Context(), l, appURI, "locals {\n  live = \"..\"\n}\n\ndependency \"vpc\" {\n  config_path = \"${local.live}/vpc\"\n}\n\ninputs = {\n  vpc_id = dependency.vpc.outputs.vpc_id\n}\n")
+
+	resp := s.Hover(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 9, Character: 33})
+	assert.Equal(t, "`config_path`: `"+filepath.Join(tmpDir, "live", "vpc")+"`\n\n"+
+		"Output declared in `"+filepath.Join("..", "..", "modules", "vpc", "outputs.tf")+"`:\n\n"+
+		"```hcl\noutput \"vpc_id\" {\n  value = aws_vpc.this.id\n}\n```", resp.Result.Contents.Value)
+}
+
+func TestState_Hover_DependencyOutput_ClosedUnitIsNotEvaluated(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"live/vpc", "live/app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.FromSlash(dir)), 0755))
+	}
+
+	marker := filepath.Join(tmpDir, "evaluated")
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "live", "vpc"), "terragrunt.hcl", "locals {\n  modules = run_cmd(\"touch\", \""+filepath.ToSlash(marker)+"\")\n}\n\nterraform {\n  source = \"${local.modules}//vpc\"\n}\n")
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "live", "app", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n\ninputs = {\n  vpc_id = dependency.vpc.outputs.vpc_id\n}\n")
+
+	// Parsing the document may read the unit it depends on, but hovering
+	// must not.
+	require.NoError(t, os.RemoveAll(marker))
+
+	resp := s.Hover(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 5, Character: 33})
+	assert.Equal(t, "`config_path`: `"+filepath.Join(tmpDir, "live", "vpc")+"`", resp.Result.Contents.Value)
+
+	assert.NoFileExists(t, marker)
+}
@@ -0,0 +1,106 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+// createDependents creates a vpc unit, and an app and db unit that depend on
+// it, and returns the directory they are in.
+func createDependents(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"vpc", "db", "app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "vpc"), "terragrunt.hcl", "locals {\n  cidr = \"10.0.0.0/16\"\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "db"), "terragrunt.hcl", "dependency \"network\" {\n  config_path = \"../vpc\"\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "app"), "terragrunt.hcl", "dependencies {\n  paths = [\"../db\", \"../vpc\"]\n}\n")
+	require.NoError(t, err)
+
+	return tmpDir
+}
+
+func TestState_Dependents(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createDependents(t)
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	require.NoError(t, s.Index(tmpDir).Scan(t.Context(), l, tmpDir, nil))
+
+	resp := s.Dependents(l, lsp.NewNumberID(1), uri.File(filepath.Join(tmpDir, "vpc", "terragrunt.hcl")))
+
+	assert.Equal(t, []lsp.Dependent{
+		{
+			URI: uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl")),
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 1, Character: 20},
+				End:   protocol.Position{Line: 1, Character: 28},
+			},
+		},
+		{
+			URI:  uri.File(filepath.Join(tmpDir, "db", "terragrunt.hcl")),
+			Name: "network",
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 1, Character: 16},
+				End:   protocol.Position{Line: 1, Character: 24},
+			},
+		},
+	}, resp.Result)
+
+	// Units without dependents get an empty list rather than null.
+	resp = s.Dependents(l, lsp.NewNumberID(2), uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl")))
+	assert.NotNil(t, resp.Result)
+	assert.Empty(t, resp.Result)
+}
+
+func TestState_TextDocumentReferences_Dependents(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createDependents(t)
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	require.NoError(t, s.Index(tmpDir).Scan(t.Context(), l, tmpDir, nil))
+
+	vpcPath := filepath.Join(tmpDir, "vpc", "terragrunt.hcl")
+	vpcURI := uri.File(vpcPath)
+
+	content, err := os.ReadFile(vpcPath)
+	require.NoError(t, err)
+
+	s.OpenDocument(t.Context(), l, vpcURI, string(content))
+
+	// The top of the file stands for the unit.
+	resp := s.TextDocumentReferences(l, lsp.NewNumberID(1), vpcURI, protocol.Position{Line: 0, Character: 0}, false)
+
+	require.Len(t, resp.Result, 2)
+	assert.Equal(t, uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl")), resp.Result[0].URI)
+	assert.Equal(t, uri.File(filepath.Join(tmpDir, "db", "terragrunt.hcl")), resp.Result[1].URI)
+
+	// References of a local are unaffected.
+	resp = s.TextDocumentReferences(l, lsp.NewNumberID(2), vpcURI, protocol.Position{Line: 1, Character: 3}, true)
+
+	require.Len(t, resp.Result, 1)
+	assert.Equal(t, vpcURI, resp.Result[0].URI)
+	assert.Equal(t, uint32(1), resp.Result[0].Range.Start.Line)
+}
@@ -0,0 +1,37 @@
+package tg_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+func TestState_TextDocumentSymbols(t *testing.T) {
+	t.Parallel()
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+
+	docURI := uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	s.OpenDocument(t.Context(), l, docURI, "locals {\n  region = \"us-east-1\"\n}\n\ninputs = {\n  region = local.region\n}\n")
+
+	resp := s.TextDocumentSymbols(l, lsp.NewNumberID(1), docURI)
+
+	require.Len(t, resp.Result, 2)
+	assert.Equal(t, "locals", resp.Result[0].Name)
+	assert.Equal(t, "region", resp.Result[0].Children[0].Name)
+	assert.Equal(t, "inputs", resp.Result[1].Name)
+	assert.Equal(t, "region", resp.Result[1].Children[0].Name)
+
+	// Unknown documents have an empty outline rather than a null one.
+	resp = s.TextDocumentSymbols(l, lsp.NewNumberID(2), uri.File(filepath.Join(t.TempDir(), "terragrunt.hcl")))
+	assert.NotNil(t, resp.Result)
+	assert.Empty(t, resp.Result)
+}
@@ -0,0 +1,84 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+func TestState_Folders(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	live, modules, nested := filepath.Join(tmpDir, "live"), filepath.Join(tmpDir, "modules"), filepath.Join(tmpDir, "live", "nested")
+
+	s := tg.NewState()
+	liveIndex := s.AddFolder(live)
+	modulesIndex := s.AddFolder(modules + string(filepath.Separator))
+	nestedIndex := s.AddFolder(nested)
+
+	assert.Same(t, liveIndex, s.AddFolder(live))
+	assert.Equal(t, []string{live, nested, modules}, s.Folders())
+
+	assert.Same(t, liveIndex, s.Index(live))
+	assert.Same(t, liveIndex, s.Index(filepath.Join(live, "app", "terragrunt.hcl")))
+	assert.Same(t, modulesIndex, s.Index(filepath.Join(modules, "vpc", "main.tf")))
+
+	// Files belong to the innermost folder that contains them.
+	assert.Same(t, nestedIndex, s.Index(filepath.Join(nested, "app", "terragrunt.hcl")))
+
+	// Files outside every folder share an index of their own.
+	outside := s.Index(filepath.Join(tmpDir, "root.hcl"))
+	assert.NotSame(t, liveIndex, outside)
+	assert.NotSame(t, modulesIndex, outside)
+	assert.Same(t, outside, s.Index(filepath.Join(tmpDir, "live-other", "terragrunt.hcl")))
+	assert.Len(t, s.Indexes(), 4)
+
+	s.RemoveFolder(nested)
+
+	_, ok := s.Folder(nested)
+	assert.False(t, ok)
+	assert.Same(t, liveIndex, s.Index(filepath.Join(nested, "app", "terragrunt.hcl")))
+}
+
+func TestState_Dependents_StayInFolder(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	live, other := filepath.Join(tmpDir, "live"), filepath.Join(tmpDir, "other")
+
+	for _, dir := range []string{filepath.Join(live, "vpc"), filepath.Join(live, "app"), filepath.Join(other, "app")} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(live, "vpc"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(live, "app"), "terragrunt.hcl", "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n")
+	require.NoError(t, err)
+
+	// A unit of another folder that points at the unit is not one of its
+	// dependents.
+	_, err = testutils.CreateFile(filepath.Join(other, "app"), "terragrunt.hcl", "dependency \"vpc\" {\n  config_path = \"../../live/vpc\"\n}\n")
+	require.NoError(t, err)
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+
+	for _, root := range []string{live, other} {
+		require.NoError(t, s.AddFolder(root).Scan(t.Context(), l, root, nil))
+	}
+
+	resp := s.Dependents(l, lsp.NewNumberID(1), uri.File(filepath.Join(live, "vpc", "terragrunt.hcl")))
+
+	require.Len(t, resp.Result, 1)
+	assert.Equal(t, uri.File(filepath.Join(live, "app", "terragrunt.hcl")), resp.Result[0].URI)
+}
@@ -0,0 +1,109 @@
+package tg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.lsp.dev/protocol"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+func TestState_Hover_Function(t *testing.T) {
+	t.Parallel()
+
+	document := `locals {
+  dir    = get_terragrunt_dir()
+  name   = upper(basename(local.dir))
+  length = 3
+  custom = my_function()
+}
+`
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, "file:///foo/terragrunt.hcl", document)
+
+	tc := []struct {
+		name     string
+		expected string
+		position protocol.Position
+	}{
+		{
+			name:     "terragrunt function",
+			position: protocol.Position{Line: 1, Character: 14},
+			expected: "```hcl\nget_terragrunt_dir() string\n```\n\n" +
+				"Returns the absolute path to the directory of the current configuration. In an included file, it is the directory of the configuration that includes it.\n\n" +
+				"Example:\n\n" +
+				"```hcl\nextra_arguments \"vars\" {\n  commands  = get_terraform_commands_that_need_vars()\n  arguments = [\"-var-file=${get_terragrunt_dir()}/common.tfvars\"]\n}\n```",
+		},
+		{
+			name:     "nested stdlib function",
+			position: protocol.Position{Line: 2, Character: 19},
+			expected: "```hcl\nbasename(path string) string\n```\n\n" +
+				"Returns the last element of `path`.\n\n" +
+				"Example:\n\n" +
+				"```hcl\nbasename(get_terragrunt_dir()) # \"vpc\"\n```",
+		},
+		{
+			name:     "attribute named like a function",
+			position: protocol.Position{Line: 3, Character: 4},
+		},
+		{
+			name:     "unknown function",
+			position: protocol.Position{Line: 4, Character: 12},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Hover(l, lsp.NewNumberID(1), "file:///foo/terragrunt.hcl", tt.position)
+			assert.Equal(t, tt.expected, resp.Result.Contents.Value)
+		})
+	}
+}
+
+func TestState_Hover_Function_SharedFiles(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		docURI   protocol.DocumentURI
+		document string
+		function string
+		position protocol.Position
+	}{
+		{
+			name:     "root file",
+			docURI:   "file:///foo/root.hcl",
+			document: "locals {\n  key = path_relative_to_include()\n}\n",
+			function: "path_relative_to_include",
+			position: protocol.Position{Line: 1, Character: 12},
+		},
+		{
+			name:     "stack file",
+			docURI:   "file:///foo/terragrunt.stack.hcl",
+			document: "unit \"vpc\" {\n  source = get_repo_root()\n  path   = \"vpc\"\n}\n",
+			function: "get_repo_root",
+			position: protocol.Position{Line: 1, Character: 14},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := testutils.NewTestLogger(t)
+			s := tg.NewState()
+			s.OpenDocument(t.Context(), l, tt.docURI, tt.document)
+
+			resp := s.Hover(l, lsp.NewNumberID(1), tt.docURI, tt.position)
+			assert.True(t, strings.HasPrefix(resp.Result.Contents.Value, "```hcl\n"+tt.function+"("), resp.Result.Contents.Value)
+		})
+	}
+}
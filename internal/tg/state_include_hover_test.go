@@ -0,0 +1,152 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+const contributingRoot = `locals {
+  region = "us-east-1"
+}
+
+remote_state {
+  backend = "s3"
+  config = {
+    bucket = "state"
+  }
+}
+
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  contents  = ""
+}
+
+inputs = {
+  region = local.region
+}
+`
+
+const multiIncludingApp = `include "root" {
+  path           = find_in_parent_folders("root.hcl")
+  merge_strategy = "deep"
+  expose         = true
+}
+
+include "env" {
+  path           = "../env.hcl"
+  merge_strategy = "no_merge"
+}
+
+inputs = {
+  root = include.root
+}
+`
+
+func TestState_Hover_Include(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "live", "app"), 0755))
+
+	_, err := testutils.CreateFile(tmpDir, "root.hcl", contributingRoot)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "live"), "env.hcl", "locals {\n  env = \"dev\"\n}\n")
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "live", "app", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, multiIncludingApp)
+
+	root := "`path`: `" + filepath.Join(tmpDir, "root.hcl") + "`\n\n" +
+		"`merge_strategy`: `deep`\n\n" +
+		"`expose`: `true`\n\n" +
+		"Contributes:\n\n" +
+		"- `remote_state`\n" +
+		"- `generate \"provider\"`\n" +
+		"- `inputs`"
+
+	tc := []struct {
+		name     string
+		expected string
+		position protocol.Position
+	}{
+		{
+			name:     "reference",
+			position: protocol.Position{Line: 12, Character: 18},
+			expected: root,
+		},
+		{
+			name:     "block type",
+			position: protocol.Position{Line: 0, Character: 3},
+			expected: root,
+		},
+		{
+			name:     "block label",
+			position: protocol.Position{Line: 0, Character: 11},
+			expected: root,
+		},
+		{
+			name:     "no merge",
+			position: protocol.Position{Line: 6, Character: 10},
+			expected: "`path`: `" + filepath.Join(tmpDir, "live", "env.hcl") + "`\n\n" +
+				"`merge_strategy`: `no_merge`\n\n" +
+				"`expose`: `false`\n\n" +
+				"Contributes nothing, as `merge_strategy` is `no_merge`.",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Hover(l, lsp.NewNumberID(1), appURI, tt.position)
+			assert.Equal(t, tt.expected, resp.Result.Contents.Value)
+		})
+	}
+}
+
+func TestState_Hover_Include_Unparsed(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "app"), 0755))
+
+	_, err := testutils.CreateFile(tmpDir, "root.hcl", "locals {\n  region = \"us-east-1\"\n}\n")
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, `include "root" {
+  path   = "../root.hcl"
+  expose = local.expose
+}
+
+inputs = {
+  region = local.missing
+}
+`)
+
+	resp := s.Hover(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 0, Character: 11})
+	assert.Equal(t, "`path`: `"+filepath.Join(tmpDir, "root.hcl")+"`\n\n"+
+		"`merge_strategy`: `shallow`\n\n"+
+		"`expose`: `local.expose`\n\n"+
+		"Contributes nothing.", resp.Result.Contents.Value)
+}
@@ -0,0 +1,269 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+const includedRoot = `locals {
+  region = "us-east-1"
+}
+
+inputs = {
+  env = "dev"
+}
+`
+
+const includingApp = `include "root" {
+  path   = find_in_parent_folders("root.hcl")
+  expose = true
+}
+
+inputs = {
+  region = include.root.locals.region
+  env    = include.root.inputs.env
+}
+`
+
+// createIncludes creates a root.hcl, and an app and db unit that include it
+// under different labels, and returns the directory they are in.
+func createIncludes(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"app", "db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0755))
+	}
+
+	_, err := testutils.CreateFile(tmpDir, "root.hcl", includedRoot)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "app"), "terragrunt.hcl", includingApp)
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "db"), "terragrunt.hcl", `include "base" {
+  path = "../root.hcl"
+}
+
+locals {
+  region = include.base.locals.region
+}
+`)
+	require.NoError(t, err)
+
+	return tmpDir
+}
+
+func TestState_Definition_IncludeAttribute(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createIncludes(t)
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+	rootURI := uri.File(filepath.Join(tmpDir, "root.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, includingApp)
+
+	tc := []struct {
+		name     string
+		position protocol.Position
+		expected protocol.Location
+	}{
+		{
+			name:     "local",
+			position: protocol.Position{Line: 6, Character: 33},
+			expected: protocol.Location{
+				URI: rootURI,
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 1, Character: 2},
+					End:   protocol.Position{Line: 1, Character: 8},
+				},
+			},
+		},
+		{
+			name:     "input",
+			position: protocol.Position{Line: 7, Character: 32},
+			expected: protocol.Location{
+				URI: rootURI,
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 5, Character: 2},
+					End:   protocol.Position{Line: 5, Character: 5},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Definition(l, lsp.NewNumberID(1), appURI, tt.position)
+
+			assert.Equal(t, tt.expected, resp.Result)
+		})
+	}
+}
+
+func TestState_Definition_IncludeAttribute_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createIncludes(t)
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+
+	content := `include "root" {
+  path   = find_in_parent_folders("root.hcl")
+  expose = true
+}
+
+inputs = {
+  zone = include.root.locals.zone
+}
+`
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, content)
+
+	resp := s.Definition(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 6, Character: 30})
+
+	// Empty response points back at the cursor position.
+	assert.Equal(t, appURI, resp.Result.URI)
+	assert.Equal(t, protocol.Position{Line: 6, Character: 30}, resp.Result.Range.Start)
+}
+
+func TestState_Hover_IncludeAttribute(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createIncludes(t)
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, includingApp)
+
+	resp := s.Hover(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 6, Character: 33})
+	assert.Equal(t, "```hcl\nregion = \"us-east-1\"\n```\n\nDefined in `../root.hcl`", resp.Result.Contents.Value)
+
+	resp = s.Hover(l, lsp.NewNumberID(2), appURI, protocol.Position{Line: 7, Character: 32})
+	assert.Equal(t, "```hcl\nenv = \"dev\"\n```\n\nDefined in `../root.hcl`", resp.Result.Contents.Value)
+}
+
+func TestState_Hover_IncludeAttribute_ClosedIncludedFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createIncludes(t)
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+	rootPath := filepath.Join(tmpDir, "root.hcl")
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, includingApp)
+	s.OpenDocument(t.Context(), l, uri.File(rootPath), includedRoot)
+	s.CloseDocument(l, uri.File(rootPath))
+
+	// The file changes on disk after it was closed, and is re-indexed.
+	require.NoError(t, os.WriteFile(rootPath, []byte("locals {\n  region = \"eu-west-1\"\n}\n"), 0644))
+
+	_, err := s.Index(rootPath).IndexFile(l, rootPath)
+	require.NoError(t, err)
+
+	resp := s.Hover(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 6, Character: 33})
+	assert.Equal(t, "```hcl\nregion = \"eu-west-1\"\n```\n\nDefined in `../root.hcl`", resp.Result.Contents.Value)
+}
+
+func TestState_TextDocumentReferences_IncludeAttribute(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createIncludes(t)
+	appURI := uri.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+	dbURI := uri.File(filepath.Join(tmpDir, "db", "terragrunt.hcl"))
+	rootURI := uri.File(filepath.Join(tmpDir, "root.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	require.NoError(t, s.Index(tmpDir).Scan(t.Context(), l, tmpDir, nil))
+
+	s.OpenDocument(t.Context(), l, appURI, includingApp)
+
+	declaration := protocol.Location{
+		URI: rootURI,
+		Range: protocol.Range{
+			Start: protocol.Position{Line: 1, Character: 2},
+			End:   protocol.Position{Line: 1, Character: 8},
+		},
+	}
+	appReference := protocol.Location{
+		URI: appURI,
+		Range: protocol.Range{
+			Start: protocol.Position{Line: 6, Character: 31},
+			End:   protocol.Position{Line: 6, Character: 37},
+		},
+	}
+	dbReference := protocol.Location{
+		URI: dbURI,
+		Range: protocol.Range{
+			Start: protocol.Position{Line: 5, Character: 31},
+			End:   protocol.Position{Line: 5, Character: 37},
+		},
+	}
+
+	t.Run("from a reference", func(t *testing.T) {
+		t.Parallel()
+
+		resp := s.TextDocumentReferences(l, lsp.NewNumberID(1), appURI, protocol.Position{Line: 6, Character: 33}, true)
+		assert.Equal(t, []protocol.Location{declaration, appReference, dbReference}, resp.Result)
+
+		resp = s.TextDocumentReferences(l, lsp.NewNumberID(2), appURI, protocol.Position{Line: 6, Character: 33}, false)
+		assert.Equal(t, []protocol.Location{appReference, dbReference}, resp.Result)
+	})
+
+	t.Run("from the declaration", func(t *testing.T) {
+		t.Parallel()
+
+		s := tg.NewState()
+		require.NoError(t, s.Index(tmpDir).Scan(t.Context(), l, tmpDir, nil))
+
+		s.OpenDocument(t.Context(), l, rootURI, includedRoot)
+
+		resp := s.TextDocumentReferences(l, lsp.NewNumberID(1), rootURI, protocol.Position{Line: 1, Character: 4}, true)
+		assert.Equal(t, []protocol.Location{declaration, appReference, dbReference}, resp.Result)
+	})
+
+	t.Run("from a cached index", func(t *testing.T) {
+		t.Parallel()
+
+		cache := workspace.NewCache(t.TempDir(), tmpDir, "1.0.0")
+
+		warm := workspace.NewIndex()
+		warm.SetCache(cache)
+		require.NoError(t, warm.Scan(t.Context(), l, tmpDir, nil))
+
+		// The files of the index are restored from the cache, without an AST.
+		s := tg.NewState()
+		index := s.AddFolder(tmpDir)
+		index.SetCache(cache)
+		require.NoError(t, index.Scan(t.Context(), l, tmpDir, nil))
+
+		file, ok := index.File(dbURI.Filename())
+		require.True(t, ok)
+		require.Nil(t, file.AST)
+
+		s.OpenDocument(t.Context(), l, rootURI, includedRoot)
+
+		resp := s.TextDocumentReferences(l, lsp.NewNumberID(1), rootURI, protocol.Position{Line: 1, Character: 4}, true)
+		assert.Equal(t, []protocol.Location{declaration, appReference, dbReference}, resp.Result)
+	})
+}
@@ -0,0 +1,179 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+const inputtingApp = `terraform {
+  source = "../../modules//vpc"
+}
+
+inputs = {
+  cidr     = "10.1.0.0/16"
+  password = "hunter2"
+  tags     = { team = "platform" }
+  unknown  = true
+}
+`
+
+func TestState_Hover_InputVariable(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"modules/vpc", "live/app", "live/remote"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.FromSlash(dir)), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "modules", "vpc"), "variables.tf", `variable "cidr" {
+  type        = string
+  description = "The CIDR block of the VPC"
+  default     = "10.0.0.0/16"
+}
+
+variable "password" {
+  sensitive = true
+}
+
+variable "tags" {
+  type = map(string)
+  default = {
+    team = "network"
+  }
+}
+`)
+	require.NoError(t, err)
+
+	appURI := uri.File(filepath.Join(tmpDir, "live", "app", "terragrunt.hcl"))
+	remoteURI := uri.File(filepath.Join(tmpDir, "live", "remote", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, appURI, inputtingApp)
+	s.OpenDocument(t.Context(), l, remoteURI, "terraform {\n  source = \"git::https://example.com/modules.git//vpc\"\n}\n\ninputs = {\n  cidr = \"10.1.0.0/16\"\n}\n")
+
+	declaredIn := "Variable declared in `" + filepath.Join("..", "..", "modules", "vpc", "variables.tf") + "`:\n\n"
+
+	tc := []struct {
+		name     string
+		docURI   protocol.DocumentURI
+		expected string
+		position protocol.Position
+	}{
+		{
+			name:     "with default",
+			docURI:   appURI,
+			position: protocol.Position{Line: 5, Character: 4},
+			expected: declaredIn +
+				"The CIDR block of the VPC\n\n" +
+				"- `type`: `string`\n" +
+				"- `default`: `\"10.0.0.0/16\"`\n" +
+				"- `sensitive`: `false`\n\n" +
+				"```hcl\nvariable \"cidr\" {\n  type        = string\n  description = \"The CIDR block of the VPC\"\n  default     = \"10.0.0.0/16\"\n}\n```",
+		},
+		{
+			name:     "required and sensitive",
+			docURI:   appURI,
+			position: protocol.Position{Line: 6, Character: 4},
+			expected: declaredIn +
+				"- `type`: `any`\n" +
+				"- `default`: none, the input is required\n" +
+				"- `sensitive`: `true`\n\n" +
+				"```hcl\nvariable \"password\" {\n  sensitive = true\n}\n```",
+		},
+		{
+			name:     "multi-line default",
+			docURI:   appURI,
+			position: protocol.Position{Line: 7, Character: 4},
+			expected: declaredIn +
+				"- `type`: `map(string)`\n" +
+				"- `default`: see the declaration\n" +
+				"- `sensitive`: `false`\n\n" +
+				"```hcl\nvariable \"tags\" {\n  type = map(string)\n  default = {\n    team = \"network\"\n  }\n}\n```",
+		},
+		{
+			name:     "undeclared variable",
+			docURI:   appURI,
+			position: protocol.Position{Line: 8, Character: 4},
+		},
+		{
+			name:     "remote module",
+			docURI:   remoteURI,
+			position: protocol.Position{Line: 5, Character: 3},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Hover(l, lsp.NewNumberID(1), tt.docURI, tt.position)
+			assert.Equal(t, tt.expected, resp.Result.Contents.Value)
+		})
+	}
+}
+
+func TestState_Hover_InputVariable_ResolvedSource(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for _, dir := range []string{"modules/vpc", "live/locals", "live/included"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.FromSlash(dir)), 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "modules", "vpc"), "variables.tf", "variable \"cidr\" {\n  type = string\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(tmpDir, "live"), "root.hcl", "terraform {\n  source = \"${get_terragrunt_dir()}/../../modules/vpc\"\n}\n")
+	require.NoError(t, err)
+
+	localsURI := uri.File(filepath.Join(tmpDir, "live", "locals", "terragrunt.hcl"))
+	includedURI := uri.File(filepath.Join(tmpDir, "live", "included", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, localsURI, "locals {\n  modules = \"../../modules\"\n}\n\nterraform {\n  source = \"${local.modules}/vpc\"\n}\n\ninputs = {\n  cidr = \"10.1.0.0/16\"\n}\n")
+	s.OpenDocument(t.Context(), l, includedURI, "include \"root\" {\n  path = find_in_parent_folders(\"root.hcl\")\n}\n\ninputs = {\n  cidr = \"10.1.0.0/16\"\n}\n")
+
+	tc := []struct {
+		name     string
+		docURI   protocol.DocumentURI
+		position protocol.Position
+	}{
+		{
+			name:     "source built from locals",
+			docURI:   localsURI,
+			position: protocol.Position{Line: 9, Character: 3},
+		},
+		{
+			name:     "terraform block from include",
+			docURI:   includedURI,
+			position: protocol.Position{Line: 5, Character: 3},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Hover(l, lsp.NewNumberID(1), tt.docURI, tt.position)
+			assert.Equal(t, "Variable declared in `"+filepath.Join("..", "..", "modules", "vpc", "variables.tf")+"`:\n\n"+
+				"- `type`: `string`\n"+
+				"- `default`: none, the input is required\n"+
+				"- `sensitive`: `false`\n\n"+
+				"```hcl\nvariable \"cidr\" {\n  type = string\n}\n```", resp.Result.Contents.Value)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go.lsp.dev/protocol"
 	"go.lsp.dev/uri"
 
+	"terragrunt-ls/internal/lsp"
 	"terragrunt-ls/internal/testutils"
 	"terragrunt-ls/internal/tg"
 )
@@ -66,7 +67,7 @@ inputs = { v = local.foo }`,
 			s := tg.NewState()
 			s.OpenDocument(t.Context(), l, docURI, tt.document)
 
-			resp := s.PrepareRename(l, 1, docURI, tt.position)
+			resp := s.PrepareRename(l, lsp.NewNumberID(1), docURI, tt.position)
 
 			if tt.wantNil {
 				assert.Nil(t, resp.Result)
@@ -95,7 +96,7 @@ func TestState_TextDocumentRename(t *testing.T) {
 		s := tg.NewState()
 		s.OpenDocument(t.Context(), l, docURI, `locals { foo = "bar" }`)
 
-		resp := s.TextDocumentRename(l, 1, docURI, protocol.Position{Line: 0, Character: 9}, "1invalid")
+		resp := s.TextDocumentRename(l, lsp.NewNumberID(1), docURI, protocol.Position{Line: 0, Character: 9}, "1invalid")
 		assert.Nil(t, resp.Result)
 	})
 
@@ -121,7 +122,7 @@ inputs = {
 		s := tg.NewState()
 		s.OpenDocument(t.Context(), l, docURI, content)
 
-		resp := s.TextDocumentRename(l, 1, docURI, protocol.Position{Line: 5, Character: 14}, "renamed")
+		resp := s.TextDocumentRename(l, lsp.NewNumberID(1), docURI, protocol.Position{Line: 5, Character: 14}, "renamed")
 		require.NotNil(t, resp.Result)
 		require.NotNil(t, resp.Result.Changes)
 
@@ -144,7 +145,7 @@ inputs = {
 		s := tg.NewState()
 		s.OpenDocument(t.Context(), l, docURI, `locals { foo = "bar" }`)
 
-		resp := s.TextDocumentRename(l, 1, docURI, protocol.Position{Line: 0, Character: 0}, "valid")
+		resp := s.TextDocumentRename(l, lsp.NewNumberID(1), docURI, protocol.Position{Line: 0, Character: 0}, "valid")
 		assert.Nil(t, resp.Result)
 	})
 
@@ -159,7 +160,7 @@ inputs = {
 		s := tg.NewState()
 		s.OpenDocument(t.Context(), l, docURI, `locals { foo = "bar" }`)
 
-		resp := s.TextDocumentRename(l, 1, docURI, protocol.Position{Line: 0, Character: 9}, "renamed")
+		resp := s.TextDocumentRename(l, lsp.NewNumberID(1), docURI, protocol.Position{Line: 0, Character: 9}, "renamed")
 		require.NotNil(t, resp.Result)
 
 		edits := resp.Result.Changes[docURI]
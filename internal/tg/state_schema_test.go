@@ -0,0 +1,124 @@
+package tg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.lsp.dev/protocol"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+func TestState_Hover_Schema(t *testing.T) {
+	t.Parallel()
+
+	document := `terraform {
+  extra_arguments "vars" {
+    commands = ["plan"]
+  }
+}
+
+dependency "vpc" {
+  config_path                             = "../vpc"
+  mock_outputs_allowed_terraform_commands = ["validate"]
+}
+
+locals {
+  region = "us-east-1"
+}
+
+iam_role = "arn:aws:iam::123456789012:role/terragrunt"
+`
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+	s.OpenDocument(t.Context(), l, "file:///foo/terragrunt.hcl", document)
+
+	tc := []struct {
+		name     string
+		expected string
+		position protocol.Position
+	}{
+		{
+			name:     "top-level block",
+			position: protocol.Position{Line: 0, Character: 3},
+			expected: "# terraform\nThe terraform block is used to configure how Terragrunt will interact with OpenTofu/Terraform.",
+		},
+		{
+			name:     "nested block",
+			position: protocol.Position{Line: 1, Character: 5},
+			expected: "# extra_arguments\nThe extra_arguments block is used to pass extra arguments and environment variables to OpenTofu/Terraform commands.",
+		},
+		{
+			name:     "attribute of nested block",
+			position: protocol.Position{Line: 2, Character: 6},
+			expected: "# commands\nThe commands attribute is the list of OpenTofu/Terraform commands the arguments are passed to.",
+		},
+		{
+			name:     "attribute of dependency",
+			position: protocol.Position{Line: 8, Character: 10},
+			expected: "# mock_outputs_allowed_terraform_commands\nThe mock_outputs_allowed_terraform_commands attribute is a list of the OpenTofu/Terraform commands mock_outputs can be used for, such as `validate` and `plan`. Mocks are used for every command when it is not set.",
+		},
+		{
+			name:     "top-level attribute",
+			position: protocol.Position{Line: 15, Character: 2},
+			expected: "# iam_role\nThe iam_role attribute is used to specify an IAM role that Terragrunt should assume prior to running OpenTofu/Terraform.",
+		},
+		{
+			name:     "local",
+			position: protocol.Position{Line: 12, Character: 3},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := s.Hover(l, lsp.NewNumberID(1), "file:///foo/terragrunt.hcl", tt.position)
+			assert.Equal(t, tt.expected, resp.Result.Contents.Value)
+		})
+	}
+}
+
+func TestState_Hover_Schema_SharedFiles(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		docURI   protocol.DocumentURI
+		document string
+		expected string
+		position protocol.Position
+	}{
+		{
+			name:     "root file",
+			docURI:   "file:///foo/root.hcl",
+			document: "remote_state {\n  backend = \"s3\"\n}\n",
+			position: protocol.Position{Line: 0, Character: 3},
+			expected: "# remote_state\n",
+		},
+		{
+			name:     "stack file",
+			docURI:   "file:///foo/terragrunt.stack.hcl",
+			document: "unit \"vpc\" {\n  source = \"../units/vpc\"\n  path   = \"vpc\"\n}\n",
+			position: protocol.Position{Line: 1, Character: 4},
+			expected: "# source\nThe source attribute is the location of the configuration to generate, as a local path or a go-getter URL.",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := testutils.NewTestLogger(t)
+			s := tg.NewState()
+			s.OpenDocument(t.Context(), l, tt.docURI, tt.document)
+
+			resp := s.Hover(l, lsp.NewNumberID(1), tt.docURI, tt.position)
+			assert.True(t, strings.HasPrefix(resp.Result.Contents.Value, tt.expected), resp.Result.Contents.Value)
+		})
+	}
+}
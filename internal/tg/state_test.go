@@ -15,6 +15,7 @@ import (
 	"terragrunt-ls/internal/lsp"
 	"terragrunt-ls/internal/testutils"
 	"terragrunt-ls/internal/tg"
+	"terragrunt-ls/internal/tg/store"
 )
 
 func TestNewState(t *testing.T) {
@@ -216,6 +217,28 @@ func TestState_UpdateDocument(t *testing.T) {
 	}
 }
 
+func TestState_UpdateDocument_KeepsLastGoodConfig(t *testing.T) {
+	t.Parallel()
+
+	state := tg.NewState()
+
+	l := testutils.NewTestLogger(t)
+
+	const docURI = protocol.DocumentURI("file:///foo/terragrunt.hcl")
+
+	diags := state.OpenDocument(t.Context(), l, docURI, "locals {\n\tfoo = \"bar\"\n}\n")
+	assert.Empty(t, diags)
+
+	// The new diagnostics are reported, but the last good config is kept.
+	diags = state.UpdateDocument(t.Context(), l, docURI, "locals {\n\tfoo = \"bar\n}\n")
+	assert.NotEmpty(t, diags)
+
+	st := state.Configs["/foo/terragrunt.hcl"]
+	require.NotNil(t, st.Cfg)
+	assert.Equal(t, map[string]any{"foo": "bar"}, st.Cfg.Locals)
+	assert.Equal(t, "locals {\n\tfoo = \"bar\n}\n", st.Document)
+}
+
 func TestState_Hover(t *testing.T) {
 	t.Parallel()
 
@@ -238,7 +261,7 @@ func TestState_Hover(t *testing.T) {
 			expected: lsp.HoverResponse{
 				Response: lsp.Response{
 					RPC: "2.0",
-					ID:  testutils.PointerOfInt(1),
+					ID:  lsp.NewNumberID(1),
 				},
 				Result: lsp.HoverResult{
 					Contents: protocol.MarkupContent{
@@ -262,7 +285,7 @@ func TestState_Hover(t *testing.T) {
 			expected: lsp.HoverResponse{
 				Response: lsp.Response{
 					RPC: "2.0",
-					ID:  testutils.PointerOfInt(1),
+					ID:  lsp.NewNumberID(1),
 				},
 				Result: lsp.HoverResult{
 					Contents: protocol.MarkupContent{
@@ -287,7 +310,7 @@ func TestState_Hover(t *testing.T) {
 
 			require.Len(t, state.Configs, 1)
 
-			hover := state.Hover(l, 1, "file:///foo/terragrunt.hcl", tt.position)
+			hover := state.Hover(l, lsp.NewNumberID(1), "file:///foo/terragrunt.hcl", tt.position)
 			assert.Equal(t, tt.expected, hover)
 		})
 	}
@@ -343,7 +366,7 @@ func TestState_Definition(t *testing.T) {
 			expected: lsp.DefinitionResponse{
 				Response: lsp.Response{
 					RPC: "2.0",
-					ID:  testutils.PointerOfInt(1),
+					ID:  lsp.NewNumberID(1),
 				},
 				Result: protocol.Location{
 					URI: unitURI,
@@ -372,7 +395,7 @@ func TestState_Definition(t *testing.T) {
 			expected: lsp.DefinitionResponse{
 				Response: lsp.Response{
 					RPC: "2.0",
-					ID:  testutils.PointerOfInt(1),
+					ID:  lsp.NewNumberID(1),
 				},
 				Result: protocol.Location{
 					URI: rootURI,
@@ -401,7 +424,7 @@ func TestState_Definition(t *testing.T) {
 			expected: lsp.DefinitionResponse{
 				Response: lsp.Response{
 					RPC: "2.0",
-					ID:  testutils.PointerOfInt(1),
+					ID:  lsp.NewNumberID(1),
 				},
 				Result: protocol.Location{
 					URI: vpcURI,
@@ -433,7 +456,7 @@ func TestState_Definition(t *testing.T) {
 
 			require.Len(t, state.Configs, 1)
 
-			definition := state.Definition(l, 1, unitURI, tt.position)
+			definition := state.Definition(l, lsp.NewNumberID(1), unitURI, tt.position)
 			assert.Equal(t, tt.expected, definition)
 		})
 	}
@@ -461,7 +484,7 @@ func TestState_TextDocumentCompletion(t *testing.T) {
 			expected: lsp.CompletionResponse{
 				Response: lsp.Response{
 					RPC: "2.0",
-					ID:  testutils.PointerOfInt(1),
+					ID:  lsp.NewNumberID(1),
 				},
 				Result: []protocol.CompletionItem{
 					{
@@ -519,7 +542,7 @@ func TestState_TextDocumentCompletion(t *testing.T) {
 				require.Empty(t, diags)
 			}
 
-			completion := state.TextDocumentCompletion(l, 1, "file:///terragrunt.hcl", tt.position)
+			completion := state.TextDocumentCompletion(l, lsp.NewNumberID(1), "file:///terragrunt.hcl", tt.position)
 			assert.Equal(t, tt.expected, completion)
 		})
 	}
@@ -539,7 +562,7 @@ func TestState_TextDocumentCompletion_StackFile(t *testing.T) {
 	diags := state.OpenDocument(t.Context(), l, stackURI, "uni")
 	require.NotEmpty(t, diags)
 
-	completion := state.TextDocumentCompletion(l, 1, stackURI, protocol.Position{Line: 0, Character: 3})
+	completion := state.TextDocumentCompletion(l, lsp.NewNumberID(1), stackURI, protocol.Position{Line: 0, Character: 3})
 
 	require.Len(t, completion.Result, 1)
 	assert.Equal(t, "unit", completion.Result[0].Label)
@@ -558,7 +581,7 @@ func TestState_TextDocumentCompletion_ValuesFile(t *testing.T) {
 	diags := state.OpenDocument(t.Context(), l, valuesURI, "loc")
 	assert.Empty(t, diags)
 
-	completion := state.TextDocumentCompletion(l, 1, valuesURI, protocol.Position{Line: 0, Character: 3})
+	completion := state.TextDocumentCompletion(l, lsp.NewNumberID(1), valuesURI, protocol.Position{Line: 0, Character: 3})
 
 	assert.Empty(t, completion.Result)
 }
@@ -623,8 +646,8 @@ func TestState_Hover_StackFile(t *testing.T) {
 	path   = "vpc"
 }`)
 
-	hover := state.Hover(l, 1, stackURI, protocol.Position{Line: 0, Character: 0})
-	assert.Empty(t, hover.Result.Contents.Value)
+	hover := state.Hover(l, lsp.NewNumberID(1), stackURI, protocol.Position{Line: 0, Character: 0})
+	assert.Equal(t, "# unit\nThe unit block references a Terragrunt unit to include in this stack.", hover.Result.Contents.Value)
 }
 
 func TestState_Hover_ValuesFile(t *testing.T) {
@@ -639,7 +662,7 @@ func TestState_Hover_ValuesFile(t *testing.T) {
 
 	_ = state.OpenDocument(t.Context(), l, valuesURI, `some_var = "hello"`)
 
-	hover := state.Hover(l, 1, valuesURI, protocol.Position{Line: 0, Character: 0})
+	hover := state.Hover(l, lsp.NewNumberID(1), valuesURI, protocol.Position{Line: 0, Character: 0})
 	assert.Empty(t, hover.Result.Contents.Value)
 }
 
@@ -659,7 +682,7 @@ func TestState_Definition_StackFile(t *testing.T) {
 }`)
 
 	pos := protocol.Position{Line: 0, Character: 0}
-	def := state.Definition(l, 1, stackURI, pos)
+	def := state.Definition(l, lsp.NewNumberID(1), stackURI, pos)
 	assert.Equal(t, stackURI, def.Result.URI)
 	assert.Equal(t, pos, def.Result.Range.Start)
 }
@@ -713,7 +736,7 @@ bar=   "baz"
 			require.Empty(t, diags)
 
 			// Request formatting
-			response := state.TextDocumentFormatting(l, 1, "file:///terragrunt.hcl")
+			response := state.TextDocumentFormatting(l, lsp.NewNumberID(1), "file:///terragrunt.hcl")
 
 			// Verify the formatting result
 			require.Len(t, response.Result, 1)
@@ -728,3 +751,57 @@ bar=   "baz"
 		})
 	}
 }
+
+func TestState_CloseDocument(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	onDisk, err := testutils.CreateFile(tmpDir, "terragrunt.hcl", "locals {\n\tfoo = \"disk\"\n}\n")
+	require.NoError(t, err)
+
+	onDiskURI := uri.File(onDisk)
+	unsavedURI := uri.File(filepath.Join(tmpDir, "unsaved", "terragrunt.hcl"))
+
+	l := testutils.NewTestLogger(t)
+	state := tg.NewState()
+
+	state.OpenDocument(t.Context(), l, onDiskURI, "locals {\n\tfoo = \"editor\"\n}\n")
+	state.OpenDocument(t.Context(), l, unsavedURI, "locals {}\n")
+
+	owner, ok := state.Owner(onDiskURI)
+	require.True(t, ok)
+	assert.Equal(t, store.OwnerEditor, owner)
+
+	// A file that exists on disk is downgraded to its content on disk.
+	state.CloseDocument(l, onDiskURI)
+
+	owner, ok = state.Owner(onDiskURI)
+	require.True(t, ok)
+	assert.Equal(t, store.OwnerDisk, owner)
+
+	document, ok := state.Document(onDiskURI)
+	require.True(t, ok)
+	assert.Equal(t, "locals {\n\tfoo = \"disk\"\n}\n", document)
+
+	// A document loaded from disk is reloaded when the file changes.
+	require.NoError(t, os.WriteFile(onDisk, []byte("locals {\n\tfoo = \"changed\"\n}\n"), 0644))
+	state.RefreshDiskDocument(l, onDiskURI)
+
+	document, ok = state.Document(onDiskURI)
+	require.True(t, ok)
+	assert.Equal(t, "locals {\n\tfoo = \"changed\"\n}\n", document)
+
+	// An open document is left alone.
+	state.RefreshDiskDocument(l, unsavedURI)
+
+	owner, ok = state.Owner(unsavedURI)
+	require.True(t, ok)
+	assert.Equal(t, store.OwnerEditor, owner)
+
+	// A file that does not exist on disk is evicted.
+	state.CloseDocument(l, unsavedURI)
+
+	_, ok = state.Owner(unsavedURI)
+	assert.False(t, ok)
+}
@@ -0,0 +1,78 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+	"terragrunt-ls/internal/tg/symbols"
+)
+
+func TestState_WorkspaceSymbols(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	live, other := filepath.Join(tmpDir, "live"), filepath.Join(tmpDir, "other")
+
+	for _, dir := range []string{filepath.Join(live, "vpc"), filepath.Join(live, "app"), filepath.Join(other, "db")} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+
+	_, err := testutils.CreateFile(filepath.Join(live, "vpc"), "terragrunt.hcl", "locals {\n  vpc_cidr = \"10.0.0.0/16\"\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(live, "app"), "terragrunt.hcl", "dependency \"network\" {\n  config_path = \"../vpc\"\n}\n")
+	require.NoError(t, err)
+
+	_, err = testutils.CreateFile(filepath.Join(other, "db"), "terragrunt.hcl", "dependency \"vpc\" {\n  config_path = \"../../live/vpc\"\n}\n")
+	require.NoError(t, err)
+
+	l := testutils.NewTestLogger(t)
+	s := tg.NewState()
+
+	for _, root := range []string{live, other} {
+		require.NoError(t, s.AddFolder(root).Scan(t.Context(), l, root, nil))
+	}
+
+	names := func(query string) []string {
+		var found []string
+		for _, symbol := range s.WorkspaceSymbols(l, lsp.NewNumberID(1), query).Result {
+			found = append(found, symbol.Name)
+		}
+
+		return found
+	}
+
+	// Symbols are searched across every workspace folder.
+	assert.Equal(t, []string{"vpc", "vpc", "vpc_cidr"}, names("vpc"))
+
+	resp := s.WorkspaceSymbols(l, lsp.NewNumberID(1), "vpc_cidr")
+	require.Len(t, resp.Result, 1)
+	assert.Equal(t, protocol.SymbolInformation{
+		Name: "vpc_cidr",
+		Kind: symbols.KindLocal,
+		Location: protocol.Location{
+			URI: uri.File(filepath.Join(live, "vpc", "terragrunt.hcl")),
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 1, Character: 2},
+				End:   protocol.Position{Line: 1, Character: 10},
+			},
+		},
+		ContainerName: "vpc/terragrunt.hcl",
+	}, resp.Result[0])
+
+	// Open documents are searched in their last parsed version.
+	appURI := uri.File(filepath.Join(live, "app", "terragrunt.hcl"))
+	s.OpenDocument(t.Context(), l, appURI, "dependency \"vpc_main\" {\n  config_path = \"../vpc\"\n}\n")
+
+	assert.Equal(t, []string{"vpc_main"}, names("vpcmain"))
+	assert.Empty(t, names("network"))
+}
@@ -4,6 +4,8 @@
 package store
 
 import (
+	"path/filepath"
+
 	"github.com/gruntwork-io/terragrunt/pkg/config"
 	"github.com/zclconf/go-cty/cty"
 
@@ -24,6 +26,32 @@ const (
 	FileTypeValues
 )
 
+// DetectFileType returns the FileType for the given filename based on its base name.
+func DetectFileType(filename string) FileType {
+	switch filepath.Base(filename) {
+	case "terragrunt.hcl":
+		return FileTypeUnit
+	case "terragrunt.stack.hcl":
+		return FileTypeStack
+	case "terragrunt.values.hcl":
+		return FileTypeValues
+	default:
+		return FileTypeUnknown
+	}
+}
+
+// Owner identifies where the content of a document comes from.
+type Owner int
+
+const (
+	// OwnerEditor is the owner of documents opened in the editor, whose
+	// content may differ from the file on disk.
+	OwnerEditor Owner = iota
+	// OwnerDisk is the owner of documents loaded from disk, which are only
+	// parsed into an AST.
+	OwnerDisk
+)
+
 type Store struct {
 	AST      *ast.IndexedAST
 	Cfg      *config.TerragruntConfig
@@ -31,4 +59,5 @@ type Store struct {
 	CfgAsCty cty.Value
 	Document string
 	FileType FileType
+	Owner    Owner
 }
@@ -0,0 +1,81 @@
+package symbols
+
+import "strings"
+
+// Bonuses added to the score of a match, on top of one point per matched
+// character.
+const (
+	// consecutiveBonus rewards characters that directly follow the previous
+	// matched character.
+	consecutiveBonus = 3
+
+	// boundaryBonus rewards characters at the start of a word, such as the
+	// `v` of `vpc` in `live/vpc` or `vpc_id`.
+	boundaryBonus = 2
+
+	// suffixBonus rewards queries that match the last segment of the name,
+	// so that `vpc` ranks `live/prod/vpc` above `live/vpc-peering`.
+	suffixBonus = 5
+
+	// exactBonus rewards queries that match the whole name.
+	exactBonus = 10
+)
+
+// Score reports whether query fuzzily matches name, that is, whether the
+// characters of query appear in name in the same order, ignoring case. The
+// returned score is higher for better matches. Every name matches the empty
+// query, with a score of zero.
+func Score(query, name string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	query, name = strings.ToLower(query), strings.ToLower(name)
+
+	q := []rune(query)
+	matched, score, last := 0, 0, -1
+
+	var prev rune
+
+	for i, r := range []rune(name) {
+		if matched < len(q) && r == q[matched] {
+			score++
+
+			if last >= 0 && i == last+1 {
+				score += consecutiveBonus
+			}
+
+			if i == 0 || isSeparator(prev) {
+				score += boundaryBonus
+			}
+
+			matched++
+			last = i
+		}
+
+		prev = r
+	}
+
+	if matched < len(q) {
+		return 0, false
+	}
+
+	switch {
+	case name == query:
+		score += exactBonus
+	case strings.HasSuffix(name, "/"+query) || strings.HasSuffix(name, "."+query):
+		score += suffixBonus
+	}
+
+	return score, true
+}
+
+// isSeparator reports whether r separates the words of a name.
+func isSeparator(r rune) bool {
+	switch r {
+	case '/', '.', '_', '-', ' ':
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,189 @@
+package symbols
+
+import (
+	"cmp"
+	"slices"
+
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/tg/store"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"go.lsp.dev/protocol"
+)
+
+// Kinds of the symbols that are only part of the outline of a document.
+const (
+	// KindBlock is the kind of the `locals`, `terraform` and `remote_state`
+	// blocks.
+	KindBlock = protocol.SymbolKindStruct
+
+	// KindFile is the kind of the `include` and `generate` blocks, which
+	// read and write files.
+	KindFile = protocol.SymbolKindFile
+
+	// KindHook is the kind of the hooks of a `terraform` block.
+	KindHook = protocol.SymbolKindEvent
+
+	// KindObject is the kind of the `inputs` attribute.
+	KindObject = protocol.SymbolKindObject
+
+	// KindProperty is the kind of the keys of `inputs`, and of the values of
+	// a values file.
+	KindProperty = protocol.SymbolKindProperty
+)
+
+// outlineBlocks maps the types of the top-level blocks that are part of the
+// outline to their kind.
+var outlineBlocks = map[string]protocol.SymbolKind{
+	"locals":       KindBlock,
+	"include":      KindFile,
+	"dependency":   KindDependency,
+	"generate":     KindFile,
+	"feature":      KindFeature,
+	"terraform":    KindBlock,
+	"remote_state": KindBlock,
+	"unit":         KindStackUnit,
+	"stack":        KindStack,
+}
+
+// hookBlocks are the types of the blocks of a `terraform` block that are
+// part of the outline.
+var hookBlocks = map[string]bool{
+	"before_hook": true,
+	"after_hook":  true,
+	"error_hook":  true,
+}
+
+// Outline returns the outline of a document of type fileType, in the order
+// the symbols appear. Blocks are named after their type and labels, like
+// `dependency "vpc"`, and hold their locals, hooks or keys as children. The
+// values of a values file are listed at the top level.
+func Outline(fileType store.FileType, indexedAST *ast.IndexedAST) []protocol.DocumentSymbol {
+	if indexedAST == nil || indexedAST.HCLFile == nil {
+		return nil
+	}
+
+	body, ok := indexedAST.HCLFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+
+	var symbols []protocol.DocumentSymbol
+
+	for _, block := range body.Blocks {
+		kind, ok := outlineBlocks[block.Type]
+		if !ok {
+			continue
+		}
+
+		symbol := blockSymbol(block, kind)
+
+		switch block.Type {
+		case "locals":
+			for _, attr := range sortedAttributes(block.Body) {
+				symbol.Children = append(symbol.Children, attributeSymbol(attr, KindLocal))
+			}
+
+		case "terraform":
+			for _, nested := range block.Body.Blocks {
+				if hookBlocks[nested.Type] {
+					symbol.Children = append(symbol.Children, blockSymbol(nested, KindHook))
+				}
+			}
+		}
+
+		symbols = append(symbols, symbol)
+	}
+
+	for _, attr := range sortedAttributes(body) {
+		switch {
+		case attr.Name == "inputs":
+			symbols = append(symbols, inputsSymbol(attr))
+		case fileType == store.FileTypeValues:
+			symbols = append(symbols, attributeSymbol(attr, KindProperty))
+		}
+	}
+
+	slices.SortFunc(symbols, func(a, b protocol.DocumentSymbol) int {
+		return cmp.Or(
+			cmp.Compare(a.Range.Start.Line, b.Range.Start.Line),
+			cmp.Compare(a.Range.Start.Character, b.Range.Start.Character),
+		)
+	})
+
+	return symbols
+}
+
+// blockSymbol returns the symbol of block, named after its type and labels,
+// without children.
+func blockSymbol(block *hclsyntax.Block, kind protocol.SymbolKind) protocol.DocumentSymbol {
+	name := block.Type
+	selection := block.TypeRange
+
+	for i, label := range block.Labels {
+		name += " \"" + label + "\""
+
+		if i == 0 {
+			selection = block.LabelRanges[0]
+		}
+	}
+
+	return protocol.DocumentSymbol{
+		Name:           name,
+		Kind:           kind,
+		Range:          ast.FromHCLRange(block.Range()),
+		SelectionRange: ast.FromHCLRange(selection),
+	}
+}
+
+// attributeSymbol returns the symbol of attr, named after it.
+func attributeSymbol(attr *hclsyntax.Attribute, kind protocol.SymbolKind) protocol.DocumentSymbol {
+	return protocol.DocumentSymbol{
+		Name:           attr.Name,
+		Kind:           kind,
+		Range:          ast.FromHCLRange(attr.SrcRange),
+		SelectionRange: ast.FromHCLRange(attr.NameRange),
+	}
+}
+
+// inputsSymbol returns the symbol of the `inputs` attribute, with its keys as
+// children when it is an object.
+func inputsSymbol(attr *hclsyntax.Attribute) protocol.DocumentSymbol {
+	symbol := attributeSymbol(attr, KindObject)
+
+	object, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return symbol
+	}
+
+	for _, item := range object.Items {
+		key, ok := ast.ObjectKeyName(item.KeyExpr)
+		if !ok {
+			continue
+		}
+
+		symbol.Children = append(symbol.Children, protocol.DocumentSymbol{
+			Name:           key,
+			Kind:           KindProperty,
+			Range:          ast.FromHCLRange(hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range())),
+			SelectionRange: ast.FromHCLRange(item.KeyExpr.Range()),
+		})
+	}
+
+	return symbol
+}
+
+// sortedAttributes returns the attributes of body in the order they appear.
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+
+	slices.SortFunc(attrs, func(a, b *hclsyntax.Attribute) int {
+		return cmp.Compare(a.SrcRange.Start.Byte, b.SrcRange.Start.Byte)
+	})
+
+	return attrs
+}
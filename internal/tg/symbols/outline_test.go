@@ -0,0 +1,183 @@
+package symbols_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/symbols"
+)
+
+// outline is a document symbol reduced to its name, kind and children.
+type outline struct {
+	name     string
+	children []outline
+	kind     protocol.SymbolKind
+}
+
+func reduce(symbols []protocol.DocumentSymbol) []outline {
+	var reduced []outline
+	for _, symbol := range symbols {
+		reduced = append(reduced, outline{name: symbol.Name, kind: symbol.Kind, children: reduce(symbol.Children)})
+	}
+
+	return reduced
+}
+
+func TestOutline(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		document string
+		expected []outline
+		fileType store.FileType
+	}{
+		{
+			name:     "unit",
+			fileType: store.FileTypeUnit,
+			document: `include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+locals {
+  region = "us-east-1"
+  env    = "prod"
+}
+
+terraform {
+  source = "../modules/app"
+
+  before_hook "init" {
+    commands = ["init"]
+    execute  = ["echo", "init"]
+  }
+
+  extra_arguments "vars" {
+    commands = ["plan"]
+  }
+
+  after_hook "done" {
+    commands = ["apply"]
+    execute  = ["echo", "done"]
+  }
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+prevent_destroy = true
+
+inputs = {
+  vpc_id   = dependency.vpc.outputs.vpc_id
+  "region" = local.region
+}
+
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  contents  = ""
+}
+
+remote_state {
+  backend = "s3"
+}
+
+feature "canary" {
+  default = false
+}
+`,
+			expected: []outline{
+				{name: `include "root"`, kind: symbols.KindFile},
+				{name: "locals", kind: symbols.KindBlock, children: []outline{
+					{name: "region", kind: symbols.KindLocal},
+					{name: "env", kind: symbols.KindLocal},
+				}},
+				{name: "terraform", kind: symbols.KindBlock, children: []outline{
+					{name: `before_hook "init"`, kind: symbols.KindHook},
+					{name: `after_hook "done"`, kind: symbols.KindHook},
+				}},
+				{name: `dependency "vpc"`, kind: symbols.KindDependency},
+				{name: "inputs", kind: symbols.KindObject, children: []outline{
+					{name: "vpc_id", kind: symbols.KindProperty},
+					{name: "region", kind: symbols.KindProperty},
+				}},
+				{name: `generate "provider"`, kind: symbols.KindFile},
+				{name: "remote_state", kind: symbols.KindBlock},
+				{name: `feature "canary"`, kind: symbols.KindFeature},
+			},
+		},
+		{
+			name:     "stack",
+			fileType: store.FileTypeStack,
+			document: `locals {
+  env = "prod"
+}
+
+unit "vpc" {
+  source = "../units/vpc"
+  path   = "vpc"
+}
+
+stack "services" {
+  source = "../stacks/services"
+  path   = "services"
+}
+`,
+			expected: []outline{
+				{name: "locals", kind: symbols.KindBlock, children: []outline{
+					{name: "env", kind: symbols.KindLocal},
+				}},
+				{name: `unit "vpc"`, kind: symbols.KindStackUnit},
+				{name: `stack "services"`, kind: symbols.KindStack},
+			},
+		},
+		{
+			name:     "values",
+			fileType: store.FileTypeValues,
+			document: "env    = \"prod\"\nregion = \"us-east-1\"\n",
+			expected: []outline{
+				{name: "env", kind: symbols.KindProperty},
+				{name: "region", kind: symbols.KindProperty},
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			indexedAST, err := ast.ParseHCLFile("terragrunt.hcl", []byte(tt.document))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, reduce(symbols.Outline(tt.fileType, indexedAST)))
+		})
+	}
+}
+
+func TestOutline_Ranges(t *testing.T) {
+	t.Parallel()
+
+	indexedAST, err := ast.ParseHCLFile("terragrunt.hcl", []byte("dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []protocol.DocumentSymbol{
+		{
+			Name: `dependency "vpc"`,
+			Kind: symbols.KindDependency,
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 0},
+				End:   protocol.Position{Line: 2, Character: 1},
+			},
+			SelectionRange: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 11},
+				End:   protocol.Position{Line: 0, Character: 16},
+			},
+		},
+	}, symbols.Outline(store.FileTypeUnit, indexedAST))
+}
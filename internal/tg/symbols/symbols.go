@@ -0,0 +1,207 @@
+// Package symbols provides the logic for finding the symbols of Terragrunt
+// files, for the outline of a document and for searching the workspace.
+package symbols
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/tg/store"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+// MaxResults is the most symbols a search returns, as clients search again
+// while the query is typed.
+const MaxResults = 256
+
+// Kinds of the symbols of Terragrunt files.
+const (
+	// KindUnit is the kind of the directory of a unit.
+	KindUnit = protocol.SymbolKindPackage
+
+	// KindStackUnit is the kind of a `unit` block of a stack.
+	KindStackUnit = protocol.SymbolKindModule
+
+	// KindStack is the kind of a `stack` block of a stack.
+	KindStack = protocol.SymbolKindNamespace
+
+	// KindDependency is the kind of a `dependency` block.
+	KindDependency = protocol.SymbolKindInterface
+
+	// KindFeature is the kind of a `feature` block.
+	KindFeature = protocol.SymbolKindBoolean
+
+	// KindLocal is the kind of a local.
+	KindLocal = protocol.SymbolKindVariable
+)
+
+// blockKinds maps the types of the labelled blocks that are symbols to their
+// kind.
+var blockKinds = map[string]protocol.SymbolKind{
+	"unit":       KindStackUnit,
+	"stack":      KindStack,
+	"dependency": KindDependency,
+	"feature":    KindFeature,
+}
+
+// Symbol is a symbol declared in a file.
+type Symbol struct {
+	Name string
+
+	// Range is the range of the name of the symbol.
+	Range protocol.Range
+
+	Kind protocol.SymbolKind
+}
+
+// Declarations returns the symbols declared in a file: the `unit`, `stack`,
+// `dependency` and `feature` blocks, and the locals, in the order they
+// appear.
+func Declarations(indexedAST *ast.IndexedAST) []Symbol {
+	if indexedAST == nil || indexedAST.HCLFile == nil {
+		return nil
+	}
+
+	var symbols []Symbol
+
+	if body, ok := indexedAST.HCLFile.Body.(*hclsyntax.Body); ok {
+		for _, block := range body.Blocks {
+			kind, ok := blockKinds[block.Type]
+			if !ok || len(block.Labels) == 0 {
+				continue
+			}
+
+			symbols = append(symbols, Symbol{
+				Name:  block.Labels[0],
+				Kind:  kind,
+				Range: ast.FromHCLRange(block.LabelRanges[0]),
+			})
+		}
+	}
+
+	for name, node := range indexedAST.Locals {
+		attr, ok := node.Node.(*hclsyntax.Attribute)
+		if !ok {
+			continue
+		}
+
+		symbols = append(symbols, Symbol{
+			Name:  name,
+			Kind:  KindLocal,
+			Range: ast.FromHCLRange(attr.NameRange),
+		})
+	}
+
+	slices.SortFunc(symbols, func(a, b Symbol) int {
+		return cmp.Or(
+			cmp.Compare(a.Range.Start.Line, b.Range.Start.Line),
+			cmp.Compare(a.Range.Start.Character, b.Range.Start.Character),
+		)
+	})
+
+	return symbols
+}
+
+// File returns the symbols of the file at path, which declares declarations:
+// the directory of the file when it is a unit, followed by declarations.
+//
+// Directories are named by their path relative to root, which is the root of
+// the workspace folder of the file, or empty when the file is outside every
+// folder. Declarations are contained in the path of the file relative to
+// root.
+func File(root, path string, fileType store.FileType, declarations []Symbol) []protocol.SymbolInformation {
+	fileURI := uri.File(path)
+	container := relativePath(root, path)
+
+	symbols := make([]protocol.SymbolInformation, 0, len(declarations)+1)
+
+	if fileType == store.FileTypeUnit {
+		symbols = append(symbols, protocol.SymbolInformation{
+			Name:     unitName(root, path),
+			Kind:     KindUnit,
+			Location: protocol.Location{URI: fileURI},
+		})
+	}
+
+	for _, symbol := range declarations {
+		symbols = append(symbols, protocol.SymbolInformation{
+			Name:          symbol.Name,
+			Kind:          symbol.Kind,
+			Location:      protocol.Location{URI: fileURI, Range: symbol.Range},
+			ContainerName: container,
+		})
+	}
+
+	return symbols
+}
+
+// Search returns the symbols whose name fuzzily matches query, best matches
+// first, and at most MaxResults of them.
+func Search(query string, symbols []protocol.SymbolInformation) []protocol.SymbolInformation {
+	type match struct {
+		symbol protocol.SymbolInformation
+		score  int
+	}
+
+	var matches []match
+
+	for _, symbol := range symbols {
+		if score, ok := Score(query, symbol.Name); ok {
+			matches = append(matches, match{symbol: symbol, score: score})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b match) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			// Among otherwise equal matches, shorter names are closer to
+			// the query.
+			cmp.Compare(utf8.RuneCountInString(a.symbol.Name), utf8.RuneCountInString(b.symbol.Name)),
+			strings.Compare(a.symbol.Name, b.symbol.Name),
+			strings.Compare(string(a.symbol.Location.URI), string(b.symbol.Location.URI)),
+			cmp.Compare(a.symbol.Location.Range.Start.Line, b.symbol.Location.Range.Start.Line),
+		)
+	})
+
+	results := make([]protocol.SymbolInformation, 0, min(len(matches), MaxResults))
+	for _, m := range matches[:min(len(matches), MaxResults)] {
+		results = append(results, m.symbol)
+	}
+
+	return results
+}
+
+// unitName returns the slash-separated path of the directory of the unit
+// configuration at path, relative to root. The unit at root is named after
+// the directory itself.
+func unitName(root, path string) string {
+	dir := filepath.Dir(path)
+
+	if root != "" && dir == filepath.Clean(root) {
+		return filepath.Base(dir)
+	}
+
+	return relativePath(root, dir)
+}
+
+// relativePath returns path relative to root, slash-separated, or path
+// itself when it is outside root.
+func relativePath(root, path string) string {
+	if root == "" {
+		return filepath.ToSlash(path)
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+
+	return filepath.ToSlash(rel)
+}
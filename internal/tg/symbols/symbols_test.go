@@ -0,0 +1,167 @@
+package symbols_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/symbols"
+)
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		query    string
+		target   string
+		expected bool
+	}{
+		{name: "empty query", query: "", target: "vpc", expected: true},
+		{name: "exact", query: "vpc", target: "vpc", expected: true},
+		{name: "case insensitive", query: "VPC", target: "live/vpc", expected: true},
+		{name: "subsequence", query: "lpv", target: "live/prod/vpc", expected: true},
+		{name: "out of order", query: "cpv", target: "vpc", expected: false},
+		{name: "missing character", query: "vpcx", target: "vpc", expected: false},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, ok := symbols.Score(tt.query, tt.target)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestScore_Ranking(t *testing.T) {
+	t.Parallel()
+
+	score := func(name string) int {
+		s, ok := symbols.Score("vpc", name)
+		require.True(t, ok)
+
+		return s
+	}
+
+	assert.Greater(t, score("vpc"), score("live/vpc"))
+	assert.Greater(t, score("live/vpc"), score("live/vpc-peering"))
+	assert.Greater(t, score("vpc_id"), score("v_p_c"))
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.FromSlash("/live")
+	path := filepath.Join(root, "app", "terragrunt.hcl")
+
+	indexedAST, err := ast.ParseHCLFile(path, []byte(`locals {
+  region = "us-east-1"
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+feature "canary" {
+  default = false
+}
+
+terraform {
+  source = "../modules/app"
+}
+`))
+	require.NoError(t, err)
+
+	fileURI := uri.File(path)
+
+	assert.Equal(t, []protocol.SymbolInformation{
+		{
+			Name:     "app",
+			Kind:     symbols.KindUnit,
+			Location: protocol.Location{URI: fileURI},
+		},
+		{
+			Name: "region",
+			Kind: symbols.KindLocal,
+			Location: protocol.Location{URI: fileURI, Range: protocol.Range{
+				Start: protocol.Position{Line: 1, Character: 2},
+				End:   protocol.Position{Line: 1, Character: 8},
+			}},
+			ContainerName: "app/terragrunt.hcl",
+		},
+		{
+			Name: "vpc",
+			Kind: symbols.KindDependency,
+			Location: protocol.Location{URI: fileURI, Range: protocol.Range{
+				Start: protocol.Position{Line: 4, Character: 11},
+				End:   protocol.Position{Line: 4, Character: 16},
+			}},
+			ContainerName: "app/terragrunt.hcl",
+		},
+		{
+			Name: "canary",
+			Kind: symbols.KindFeature,
+			Location: protocol.Location{URI: fileURI, Range: protocol.Range{
+				Start: protocol.Position{Line: 8, Character: 8},
+				End:   protocol.Position{Line: 8, Character: 16},
+			}},
+			ContainerName: "app/terragrunt.hcl",
+		},
+	}, symbols.File(root, path, store.FileTypeUnit, symbols.Declarations(indexedAST)))
+}
+
+func TestFile_Stack(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.FromSlash("/live")
+	path := filepath.Join(root, "terragrunt.stack.hcl")
+
+	indexedAST, err := ast.ParseHCLFile(path, []byte(`unit "vpc" {
+  source = "../units/vpc"
+  path   = "vpc"
+}
+
+stack "services" {
+  source = "../stacks/services"
+  path   = "services"
+}
+`))
+	require.NoError(t, err)
+
+	found := symbols.File(root, path, store.FileTypeStack, symbols.Declarations(indexedAST))
+
+	require.Len(t, found, 2)
+	assert.Equal(t, "vpc", found[0].Name)
+	assert.Equal(t, symbols.KindStackUnit, found[0].Kind)
+	assert.Equal(t, "services", found[1].Name)
+	assert.Equal(t, symbols.KindStack, found[1].Kind)
+	assert.Equal(t, "terragrunt.stack.hcl", found[1].ContainerName)
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	all := []protocol.SymbolInformation{
+		{Name: "live/vpc-peering", Kind: symbols.KindUnit},
+		{Name: "vpc_cidr", Kind: symbols.KindLocal},
+		{Name: "live/vpc", Kind: symbols.KindUnit},
+		{Name: "vpc", Kind: symbols.KindDependency},
+		{Name: "db", Kind: symbols.KindDependency},
+	}
+
+	var names []string
+	for _, symbol := range symbols.Search("vpc", all) {
+		names = append(names, symbol.Name)
+	}
+
+	assert.Equal(t, []string{"vpc", "live/vpc", "vpc_cidr", "live/vpc-peering"}, names)
+	assert.Len(t, symbols.Search("", all), len(all))
+}
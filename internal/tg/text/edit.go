@@ -0,0 +1,82 @@
+package text
+
+import (
+	"unicode/utf8"
+
+	"go.lsp.dev/protocol"
+)
+
+// ApplyEdit replaces the text covered by r in document with newText.
+//
+// Positions are interpreted the way the LSP spec defines them: characters are
+// counted in UTF-16 code units, and positions past the end of a line or of the
+// document are clamped to that end.
+func ApplyEdit(document string, r protocol.Range, newText string) string {
+	start := OffsetAt(document, r.Start)
+	end := OffsetAt(document, r.End)
+
+	if end < start {
+		start, end = end, start
+	}
+
+	return document[:start] + newText + document[end:]
+}
+
+// OffsetAt converts an LSP position into a byte offset into document.
+func OffsetAt(document string, position protocol.Position) int {
+	lineStart := 0
+
+	for line := uint32(0); line < position.Line; line++ {
+		next, ok := nextLineStart(document, lineStart)
+		if !ok {
+			return len(document)
+		}
+
+		lineStart = next
+	}
+
+	offset := lineStart
+	units := uint32(0)
+
+	for offset < len(document) && units < position.Character {
+		r, size := utf8.DecodeRuneInString(document[offset:])
+		if r == '\n' || r == '\r' {
+			break
+		}
+
+		units += utf16Len(r)
+		offset += size
+	}
+
+	return offset
+}
+
+// nextLineStart returns the offset of the line following the one starting at
+// offset, treating `\n`, `\r\n` and `\r` as line terminators.
+func nextLineStart(document string, offset int) (int, bool) {
+	for i := offset; i < len(document); i++ {
+		switch document[i] {
+		case '\n':
+			return i + 1, true
+		case '\r':
+			if i+1 < len(document) && document[i+1] == '\n' {
+				return i + 2, true
+			}
+
+			return i + 1, true
+		}
+	}
+
+	return len(document), false
+}
+
+// utf16Len returns the number of UTF-16 code units needed to encode r.
+func utf16Len(r rune) uint32 {
+	const maxBMPRune = 0xFFFF
+
+	if r > maxBMPRune {
+		return 2
+	}
+
+	return 1
+}
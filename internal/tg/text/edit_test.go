@@ -0,0 +1,131 @@
+package text_test
+
+import (
+	"terragrunt-ls/internal/tg/text"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.lsp.dev/protocol"
+)
+
+func TestOffsetAt(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		document string
+		position protocol.Position
+		expected int
+	}{
+		{
+			name:     "start of document",
+			document: "foo\nbar",
+			position: protocol.Position{Line: 0, Character: 0},
+			expected: 0,
+		},
+		{
+			name:     "second line",
+			document: "foo\nbar",
+			position: protocol.Position{Line: 1, Character: 2},
+			expected: 6,
+		},
+		{
+			name:     "character past end of line is clamped",
+			document: "foo\nbar",
+			position: protocol.Position{Line: 0, Character: 10},
+			expected: 3,
+		},
+		{
+			name:     "line past end of document is clamped",
+			document: "foo\nbar",
+			position: protocol.Position{Line: 5, Character: 0},
+			expected: 7,
+		},
+		{
+			name:     "crlf line endings",
+			document: "foo\r\nbar",
+			position: protocol.Position{Line: 1, Character: 1},
+			expected: 6,
+		},
+		{
+			name:     "multi-byte character in the BMP",
+			document: "é = 1",
+			position: protocol.Position{Line: 0, Character: 1},
+			expected: 2,
+		},
+		{
+			name:     "character outside the BMP counts as two units",
+			document: "a🚀b",
+			position: protocol.Position{Line: 0, Character: 3},
+			expected: 5,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, text.OffsetAt(tt.document, tt.position))
+		})
+	}
+}
+
+func TestApplyEdit(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		document string
+		newText  string
+		expected string
+		r        protocol.Range
+	}{
+		{
+			name:     "insert",
+			document: "locals {\n}",
+			r: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 8},
+				End:   protocol.Position{Line: 0, Character: 8},
+			},
+			newText:  "\n\tfoo = \"bar\"",
+			expected: "locals {\n\tfoo = \"bar\"\n}",
+		},
+		{
+			name:     "replace across lines",
+			document: "locals {\n\tfoo = \"bar\"\n}",
+			r: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 0},
+				End:   protocol.Position{Line: 1, Character: 4},
+			},
+			newText:  "inputs = {\n\tbaz",
+			expected: "inputs = {\n\tbaz = \"bar\"\n}",
+		},
+		{
+			name:     "delete",
+			document: "foo = \"bar\"",
+			r: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 3},
+				End:   protocol.Position{Line: 0, Character: 11},
+			},
+			expected: "foo",
+		},
+		{
+			name:     "replace after surrogate pair",
+			document: "x = \"🚀\" # rocket",
+			r: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 11},
+				End:   protocol.Position{Line: 0, Character: 17},
+			},
+			newText:  "ship",
+			expected: "x = \"🚀\" # ship",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, text.ApplyEdit(tt.document, tt.r, tt.newText))
+		})
+	}
+}
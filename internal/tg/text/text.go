@@ -38,3 +38,27 @@ func isWordChar(c byte) bool {
 func WrapAsHCLCodeFence(s string) string {
 	return "```hcl\n" + s + "\n```"
 }
+
+// IsCursorCall reports whether the word at the cursor is followed by an
+// opening parenthesis, which makes it the name of a function call.
+func IsCursorCall(document string, position protocol.Position) bool {
+	scanner := bufio.NewScanner(strings.NewReader(document))
+	for i := 0; i <= int(position.Line); i++ {
+		scanner.Scan()
+	}
+
+	line := scanner.Text()
+
+	end := int(position.Character)
+	if end > len(line) {
+		return false
+	}
+
+	for end < len(line) && isWordChar(line[end]) {
+		end++
+	}
+
+	rest := strings.TrimLeft(line[end:], " \t")
+
+	return strings.HasPrefix(rest, "(")
+}
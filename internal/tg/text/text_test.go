@@ -64,3 +64,56 @@ func TestGetCursorWord(t *testing.T) {
 		})
 	}
 }
+
+func TestIsCursorCall(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name     string
+		document string
+		position protocol.Position
+		expected bool
+	}{
+		{
+			name:     "call",
+			document: `path = find_in_parent_folders("root.hcl")`,
+			position: protocol.Position{Line: 0, Character: 10},
+			expected: true,
+		},
+		{
+			name:     "space before parenthesis",
+			document: "x = run_cmd (\"echo\")",
+			position: protocol.Position{Line: 0, Character: 6},
+			expected: true,
+		},
+		{
+			name:     "line past end of document",
+			document: "x = jsondecode(file(\"a.json\"))",
+			position: protocol.Position{Line: 1, Character: 0},
+		},
+		{
+			name:     "call in an argument",
+			document: "x = jsondecode(file(\"a.json\"))",
+			position: protocol.Position{Line: 0, Character: 16},
+			expected: true,
+		},
+		{
+			name:     "attribute",
+			document: "values = {}",
+			position: protocol.Position{Line: 0, Character: 2},
+		},
+		{
+			name:     "past end of line",
+			document: "length(",
+			position: protocol.Position{Line: 0, Character: 12},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, text.IsCursorCall(tt.document, tt.position))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package tg
+
+import (
+	"os"
+	"path/filepath"
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"go.lsp.dev/protocol"
+)
+
+// ValidationSource is the source of diagnostics reported by ValidateStore.
+const ValidationSource = "Terragrunt"
+
+// ValidateStore runs the checks that are too slow to run on every change,
+// as they hit the filesystem, and returns their diagnostics.
+//
+// It checks that dependencies point at existing units, and that local
+// sources of units, stacks and `terraform` blocks exist.
+func ValidateStore(l logger.Logger, docURI protocol.DocumentURI, st store.Store) []protocol.Diagnostic {
+	diags := []protocol.Diagnostic{}
+
+	if st.AST == nil || st.AST.HCLFile == nil {
+		return diags
+	}
+
+	body, ok := st.AST.HCLFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return diags
+	}
+
+	dir := filepath.Dir(docURI.Filename())
+
+	switch st.FileType {
+	case store.FileTypeUnit:
+		if st.Cfg == nil {
+			return diags
+		}
+
+		for _, dep := range st.Cfg.TerragruntDependencies {
+			if dep.ConfigPath.IsNull() || !dep.ConfigPath.IsKnown() || dep.ConfigPath.Type() != cty.String {
+				continue
+			}
+
+			path := workspace.ResolveConfigPath(dir, dep.ConfigPath.AsString())
+			if fileExists(path) {
+				continue
+			}
+
+			l.Debug(
+				"Dependency does not exist",
+				"dependency", dep.Name,
+				"path", path,
+			)
+
+			if r, ok := blockAttributeRange(body, "dependency", dep.Name, "config_path"); ok {
+				diags = append(diags, newValidationDiagnostic(r, protocol.DiagnosticSeverityError, "Dependency not found: no Terragrunt configuration at "+path))
+			}
+		}
+
+		if st.Cfg.Terraform != nil && st.Cfg.Terraform.Source != nil {
+			diags = appendMissingSource(diags, body, dir, "terraform", "", *st.Cfg.Terraform.Source)
+		}
+
+	case store.FileTypeStack:
+		if st.StackCfg == nil {
+			return diags
+		}
+
+		for _, unit := range st.StackCfg.Units {
+			diags = appendMissingSource(diags, body, dir, "unit", unit.Name, unit.Source)
+		}
+
+		for _, stack := range st.StackCfg.Stacks {
+			diags = appendMissingSource(diags, body, dir, "stack", stack.Name, stack.Source)
+		}
+
+	case store.FileTypeValues, store.FileTypeUnknown:
+	}
+
+	return diags
+}
+
+// appendMissingSource appends a diagnostic for the `source` attribute of a
+// block when it is a local path that does not exist.
+func appendMissingSource(diags []protocol.Diagnostic, body *hclsyntax.Body, dir, blockType, label, source string) []protocol.Diagnostic {
+	if !workspace.IsLocalSource(source) {
+		return diags
+	}
+
+	path := source
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return diags
+	}
+
+	r, ok := blockAttributeRange(body, blockType, label, "source")
+	if !ok {
+		return diags
+	}
+
+	return append(diags, newValidationDiagnostic(r, protocol.DiagnosticSeverityWarning, "Source not found: "+path+" does not exist"))
+}
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+// blockAttributeRange returns the range of the value of an attribute of the
+// first top-level block with the given type and label. An empty label
+// matches blocks of any label.
+func blockAttributeRange(body *hclsyntax.Body, blockType, label, attrName string) (protocol.Range, bool) {
+	for _, block := range body.Blocks {
+		if block.Type != blockType {
+			continue
+		}
+
+		if label != "" && (len(block.Labels) == 0 || block.Labels[0] != label) {
+			continue
+		}
+
+		attr, ok := block.Body.Attributes[attrName]
+		if !ok {
+			continue
+		}
+
+		return ast.FromHCLRange(attr.Expr.Range()), true
+	}
+
+	return protocol.Range{}, false
+}
+
+func newValidationDiagnostic(r protocol.Range, severity protocol.DiagnosticSeverity, message string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range:    r,
+		Severity: severity,
+		Source:   ValidationSource,
+		Message:  message,
+	}
+}
@@ -0,0 +1,145 @@
+package tg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg"
+)
+
+func TestValidateStore(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "vpc"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "modules", "app"), 0755))
+
+	_, err := testutils.CreateFile(filepath.Join(tmpDir, "vpc"), "terragrunt.hcl", "")
+	require.NoError(t, err)
+
+	unitDir := filepath.Join(tmpDir, "app")
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+
+	tc := []struct {
+		name     string
+		filename string
+		document string
+		expected []protocol.Diagnostic
+	}{
+		{
+			name:     "existing dependency and local source",
+			filename: "terragrunt.hcl",
+			document: `dependency "vpc" {
+  config_path = "../vpc"
+}
+
+terraform {
+  source = "../modules/app"
+}
+`,
+			expected: []protocol.Diagnostic{},
+		},
+		{
+			name:     "missing dependency",
+			filename: "terragrunt.hcl",
+			document: `dependency "db" {
+  config_path = "../db"
+}
+`,
+			expected: []protocol.Diagnostic{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 1, Character: 16},
+						End:   protocol.Position{Line: 1, Character: 23},
+					},
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   tg.ValidationSource,
+					Message:  "Dependency not found: no Terragrunt configuration at " + filepath.Join(tmpDir, "db", "terragrunt.hcl"),
+				},
+			},
+		},
+		{
+			name:     "non-string dependency path",
+			filename: "terragrunt.hcl",
+			document: `dependency "db" {
+  config_path = 42
+}
+`,
+			expected: []protocol.Diagnostic{},
+		},
+		{
+			name:     "missing local source",
+			filename: "terragrunt.hcl",
+			document: `terraform {
+  source = "../modules/missing"
+}
+`,
+			expected: []protocol.Diagnostic{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 1, Character: 11},
+						End:   protocol.Position{Line: 1, Character: 31},
+					},
+					Severity: protocol.DiagnosticSeverityWarning,
+					Source:   tg.ValidationSource,
+					Message:  "Source not found: " + filepath.Join(tmpDir, "modules", "missing") + " does not exist",
+				},
+			},
+		},
+		{
+			name:     "remote source",
+			filename: "terragrunt.hcl",
+			document: `terraform {
+  source = "git::https://example.com/modules.git//app?ref=v1.0.0"
+}
+`,
+			expected: []protocol.Diagnostic{},
+		},
+		{
+			name:     "missing stack unit source",
+			filename: "terragrunt.stack.hcl",
+			document: `unit "app" {
+  source = "../modules/app"
+  path   = "app"
+}
+
+unit "missing" {
+  source = "../modules/missing"
+  path   = "missing"
+}
+`,
+			expected: []protocol.Diagnostic{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 6, Character: 11},
+						End:   protocol.Position{Line: 6, Character: 31},
+					},
+					Severity: protocol.DiagnosticSeverityWarning,
+					Source:   tg.ValidationSource,
+					Message:  "Source not found: " + filepath.Join(tmpDir, "modules", "missing") + " does not exist",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := testutils.NewTestLogger(t)
+			docURI := uri.File(filepath.Join(unitDir, tt.filename))
+
+			st, _ := tg.NewState().ParseDocument(t.Context(), l, docURI, tt.document)
+
+			assert.Equal(t, tt.expected, tg.ValidateStore(l, docURI, st))
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/symbols"
+)
+
+const (
+	// cacheRootHashLen is the number of bytes of the hash of the root of a
+	// workspace folder that name its cache file.
+	cacheRootHashLen = 8
+
+	// cacheFormat is the version of the format of cache entries. Bump it
+	// whenever the fields of cacheEntry, or what NewFile, Symbols or
+	// IncludeReferences compute for them, change, so that entries written by
+	// older code are never restored.
+	cacheFormat = 1
+)
+
+// Cache stores the indexed files of a workspace folder on disk, so that a
+// later scan of the folder only parses the files that changed.
+//
+// Files are keyed by their path and the hash of their content, and the
+// whole cache is ignored when it was written with another format or by
+// another build of terragrunt-ls. Files restored from the cache have no AST.
+type Cache struct {
+	// path is the path of the cache file.
+	path string
+
+	// key identifies the format and the build the cache is valid for.
+	key string
+}
+
+// NewCache returns the cache of the workspace folder at root, stored in dir,
+// for the given build of terragrunt-ls. Nothing is read or written until the
+// folder is scanned.
+func NewCache(dir, root, build string) *Cache {
+	sum := sha256.Sum256([]byte(filepath.Clean(root)))
+
+	return &Cache{
+		path: filepath.Join(dir, "index-"+hex.EncodeToString(sum[:cacheRootHashLen])+".json"),
+		key:  fmt.Sprintf("%d/%s", cacheFormat, build),
+	}
+}
+
+// Path returns the path of the cache file.
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// cacheContents is the content of a cache file.
+type cacheContents struct {
+	// Files maps the absolute paths of the indexed files to their entry.
+	Files map[string]cacheEntry `json:"files"`
+
+	// Key is the key of the Cache that wrote the file.
+	Key string `json:"key"`
+}
+
+// cacheEntry is an indexed file, without its AST.
+type cacheEntry struct {
+	// Hash is the hash of the content the file was indexed from.
+	Hash string `json:"hash"`
+
+	Source            string                 `json:"source,omitempty"`
+	Includes          []Include              `json:"includes,omitempty"`
+	Dependencies      []Dependency           `json:"dependencies,omitempty"`
+	Symbols           []symbols.Symbol       `json:"symbols,omitempty"`
+	IncludeReferences []ast.IncludeReference `json:"includeReferences,omitempty"`
+}
+
+// load returns the entries of the cache. A cache with another key is
+// empty, and a cache that does not exist is empty without error.
+func (c *Cache) load() (map[string]cacheEntry, error) {
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var contents cacheContents
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", c.path, err)
+	}
+
+	if contents.Key != c.key {
+		return nil, nil
+	}
+
+	return contents.Files, nil
+}
+
+// save replaces the entries of the cache with files. Files that were not
+// read from disk are left out.
+//
+// The cache file is replaced atomically, so that a session that reads it
+// concurrently never sees a partial write.
+func (c *Cache) save(files []*File) error {
+	contents := cacheContents{
+		Files: make(map[string]cacheEntry, len(files)),
+		Key:   c.key,
+	}
+
+	for _, file := range files {
+		if file.hash == "" {
+			continue
+		}
+
+		contents.Files[file.Path] = cacheEntry{
+			Hash:              file.hash,
+			Source:            file.Source,
+			Includes:          file.Includes,
+			Dependencies:      file.Dependencies,
+			Symbols:           file.Symbols,
+			IncludeReferences: file.IncludeReferences,
+		}
+	}
+
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// valid reports whether the entry still holds the file with the given
+// content. The paths of includes resolved through `find_in_parent_folders`
+// depend on the files around it, so the entry is stale when one of them is
+// gone.
+func (e cacheEntry) valid(content []byte) bool {
+	if e.Hash != hashContent(content) {
+		return false
+	}
+
+	for _, include := range e.Includes {
+		if _, err := os.Stat(include.Path); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// file returns the indexed file at path the entry holds.
+func (e cacheEntry) file(path string) *File {
+	return &File{
+		Path:              path,
+		Includes:          e.Includes,
+		Dependencies:      e.Dependencies,
+		Source:            e.Source,
+		Symbols:           e.Symbols,
+		IncludeReferences: e.IncludeReferences,
+		hash:              e.Hash,
+		FileType:          store.DetectFileType(path),
+	}
+}
+
+// hashContent returns the hash of the content of a file.
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,177 @@
+package workspace_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// createCachedTree creates a root.hcl included by an app unit that depends on
+// a vpc unit, and returns the directory they are in.
+func createCachedTree(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+
+	createTree(t, tmpDir, map[string]string{
+		"root.hcl":                "locals {\n  region = \"us-east-1\"\n}\n",
+		"live/vpc/terragrunt.hcl": "locals {\n  cidr = \"10.0.0.0/16\"\n}\n",
+		"live/app/terragrunt.hcl": `include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+inputs = {
+  region = include.root.locals.region
+}
+`,
+	})
+
+	return tmpDir
+}
+
+// scan scans root into a new index with cache, and returns the files that
+// were restored from the cache, which are the ones without an AST.
+func scan(t *testing.T, cache *workspace.Cache, root string) (*workspace.Index, []string) {
+	t.Helper()
+
+	index := workspace.NewIndex()
+	index.SetCache(cache)
+
+	require.NoError(t, index.Scan(t.Context(), testutils.NewTestLogger(t), root, nil))
+
+	var restored []string
+
+	for _, file := range index.Files() {
+		if file.AST == nil {
+			restored = append(restored, file.Path)
+		}
+	}
+
+	return index, restored
+}
+
+func TestIndex_ScanCache(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createCachedTree(t)
+	root := filepath.Join(tmpDir, "live")
+	cache := workspace.NewCache(t.TempDir(), root, "1.0.0")
+
+	parsed, restored := scan(t, cache, root)
+	assert.Empty(t, restored)
+	assert.FileExists(t, cache.Path())
+
+	app := filepath.Join(root, "app", "terragrunt.hcl")
+	vpc := filepath.Join(root, "vpc", "terragrunt.hcl")
+
+	require.NoError(t, os.WriteFile(vpc, []byte("locals {\n  cidr = \"10.1.0.0/16\"\n}\n"), 0644))
+
+	// Only the file that changed is parsed again.
+	index, restored := scan(t, cache, root)
+	assert.Equal(t, []string{app, filepath.Join(tmpDir, "root.hcl")}, restored)
+
+	// Restored files hold what parsing them found.
+	want, ok := parsed.File(app)
+	require.True(t, ok)
+
+	got, ok := index.File(app)
+	require.True(t, ok)
+
+	assert.Equal(t, want.Includes, got.Includes)
+	assert.Equal(t, want.Dependencies, got.Dependencies)
+	assert.Equal(t, want.Symbols, got.Symbols)
+	assert.Equal(t, want.IncludeReferences, got.IncludeReferences)
+	assert.Equal(t, want.FileType, got.FileType)
+	assert.Equal(t, []string{app}, paths(index.Dependents(vpc)))
+}
+
+func TestIndex_ScanCache_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		change func(t *testing.T, tmpDir string, cache *workspace.Cache)
+		name   string
+		// version is the version the cache is read with.
+		version  string
+		restored []string
+	}{
+		{
+			name:    "corrupt",
+			version: "1.0.0",
+			change: func(t *testing.T, _ string, cache *workspace.Cache) {
+				t.Helper()
+
+				require.NoError(t, os.WriteFile(cache.Path(), []byte("{\"files\": ["), 0644))
+			},
+		},
+		{
+			name:    "other version",
+			version: "2.0.0",
+			change:  func(*testing.T, string, *workspace.Cache) {},
+		},
+		{
+			name:    "included file removed",
+			version: "1.0.0",
+			change: func(t *testing.T, tmpDir string, _ *workspace.Cache) {
+				t.Helper()
+
+				require.NoError(t, os.Remove(filepath.Join(tmpDir, "root.hcl")))
+			},
+			restored: []string{filepath.Join("live", "vpc", "terragrunt.hcl")},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := createCachedTree(t)
+			root := filepath.Join(tmpDir, "live")
+			cacheDir := t.TempDir()
+			cache := workspace.NewCache(cacheDir, root, "1.0.0")
+
+			scan(t, cache, root)
+			tt.change(t, tmpDir, cache)
+
+			index, restored := scan(t, workspace.NewCache(cacheDir, root, tt.version), root)
+
+			var expected []string
+			for _, path := range tt.restored {
+				expected = append(expected, filepath.Join(tmpDir, path))
+			}
+
+			assert.Equal(t, expected, restored)
+			assert.NotEmpty(t, index.Files())
+
+			// The cache is written again, and restores every file next time.
+			_, restored = scan(t, workspace.NewCache(cacheDir, root, tt.version), root)
+			assert.Equal(t, paths(index.Files()), restored)
+		})
+	}
+}
+
+func TestIndex_ScanCache_Unwritable(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := createCachedTree(t)
+	root := filepath.Join(tmpDir, "live")
+
+	// The cache directory cannot be created under a file.
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0644))
+
+	index, restored := scan(t, workspace.NewCache(filepath.Join(blocker, "cache"), root, "1.0.0"), root)
+
+	assert.Empty(t, restored)
+	assert.Len(t, index.Files(), 3)
+}
@@ -0,0 +1,111 @@
+package workspace
+
+// Link is a dependency of a unit, as part of a cycle.
+type Link struct {
+	// Path is the absolute path of the configuration of the unit that has
+	// the dependency.
+	Path string
+
+	Dependency Dependency
+}
+
+// Cycle is a chain of dependencies that leads from a unit back to itself.
+// The first link is held by the unit, and each other link by the unit the
+// previous link points at.
+type Cycle []Link
+
+// Cycles returns, for each dependency of file that leads back to it, the
+// shortest cycle that starts with that dependency.
+//
+// The dependencies of file are taken from file itself, and those of other
+// units from the index, so that a document can be checked against the
+// workspace before it is saved.
+func (i *Index) Cycles(file *File) []Cycle {
+	var cycles []Cycle
+
+	for _, dep := range file.Dependencies {
+		first := Link{Path: file.Path, Dependency: dep}
+
+		if rest, ok := i.shortestPath(file, dep.Path); ok {
+			cycles = append(cycles, append(Cycle{first}, rest...))
+		}
+	}
+
+	return cycles
+}
+
+// shortestPath returns the shortest chain of dependencies from the unit at
+// from back to file, which is empty when from is file.
+func (i *Index) shortestPath(file *File, from string) ([]Link, bool) {
+	if from == file.Path {
+		return nil, true
+	}
+
+	// via maps each unit reached to the link it was first reached through.
+	via := map[string]Link{from: {}}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range i.dependencies(file, current) {
+			if _, ok := via[dep.Path]; ok {
+				continue
+			}
+
+			via[dep.Path] = Link{Path: current, Dependency: dep}
+
+			if dep.Path != file.Path {
+				queue = append(queue, dep.Path)
+
+				continue
+			}
+
+			var links []Link
+			for at := file.Path; at != from; at = via[at].Path {
+				links = append([]Link{via[at]}, links...)
+			}
+
+			return links, true
+		}
+	}
+
+	return nil, false
+}
+
+// dependencies returns the dependencies of the unit at path, taking those of
+// file from file itself.
+func (i *Index) dependencies(file *File, path string) []Dependency {
+	if path == file.Path {
+		return file.Dependencies
+	}
+
+	indexed, ok := i.File(path)
+	if !ok {
+		return nil
+	}
+
+	return indexed.Dependencies
+}
+
+// Reachable returns the absolute paths of the unit configurations reached by
+// following the dependencies of file, directly or through other units.
+func (i *Index) Reachable(file *File) map[string]bool {
+	reached := map[string]bool{}
+	queue := []string{file.Path}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range i.dependencies(file, current) {
+			if !reached[dep.Path] {
+				reached[dep.Path] = true
+				queue = append(queue, dep.Path)
+			}
+		}
+	}
+
+	return reached
+}
@@ -0,0 +1,75 @@
+package workspace_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// links returns the units holding each link of a cycle, and the unit the last
+// link points at, relative to dir.
+func links(t *testing.T, dir string, cycle workspace.Cycle) []string {
+	t.Helper()
+
+	result := make([]string, 0, len(cycle)+1)
+
+	for _, link := range cycle {
+		rel, err := filepath.Rel(dir, filepath.Dir(link.Path))
+		require.NoError(t, err)
+
+		result = append(result, filepath.ToSlash(rel))
+	}
+
+	rel, err := filepath.Rel(dir, filepath.Dir(cycle[len(cycle)-1].Dependency.Path))
+	require.NoError(t, err)
+
+	return append(result, filepath.ToSlash(rel))
+}
+
+func TestIndex_Cycles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	createTree(t, tmpDir, map[string]string{
+		"app/terragrunt.hcl":  "dependency \"db\" {\n  config_path = \"../db\"\n}\n\ndependency \"self\" {\n  config_path = \".\"\n}\n\ndependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n",
+		"db/terragrunt.hcl":   "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n",
+		"vpc/terragrunt.hcl":  "dependencies {\n  paths = [\"../dns\"]\n}\n",
+		"dns/terragrunt.hcl":  "dependency \"app\" {\n  config_path = \"../app\"\n}\n",
+		"edge/terragrunt.hcl": "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n",
+	})
+
+	l := testutils.NewTestLogger(t)
+
+	index := workspace.NewIndex()
+	require.NoError(t, index.Scan(t.Context(), l, tmpDir, nil))
+
+	app, ok := index.File(filepath.Join(tmpDir, "app", "terragrunt.hcl"))
+	require.True(t, ok)
+
+	cycles := index.Cycles(app)
+	require.Len(t, cycles, 3)
+
+	// Each dependency that leads back gets the shortest cycle through it.
+	assert.Equal(t, []string{"app", "db", "vpc", "dns", "app"}, links(t, tmpDir, cycles[0]))
+	assert.Equal(t, []string{"app", "app"}, links(t, tmpDir, cycles[1]))
+	assert.Equal(t, []string{"app", "vpc", "dns", "app"}, links(t, tmpDir, cycles[2]))
+	assert.Equal(t, "vpc", cycles[0][1].Dependency.Name)
+	assert.Empty(t, cycles[0][2].Dependency.Name)
+
+	// Units that only lead into a cycle are not part of it.
+	edge, ok := index.File(filepath.Join(tmpDir, "edge", "terragrunt.hcl"))
+	require.True(t, ok)
+	assert.Empty(t, index.Cycles(edge))
+
+	assert.Equal(t, map[string]bool{
+		filepath.Join(tmpDir, "vpc", "terragrunt.hcl"): true,
+		filepath.Join(tmpDir, "dns", "terragrunt.hcl"): true,
+		filepath.Join(tmpDir, "app", "terragrunt.hcl"): true,
+		filepath.Join(tmpDir, "db", "terragrunt.hcl"):  true,
+	}, index.Reachable(edge))
+}
@@ -0,0 +1,143 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// UnitConfigFile is the name of the configuration file of a unit.
+const UnitConfigFile = "terragrunt.hcl"
+
+// errNotFound is returned by find_in_parent_folders when no parent folder
+// has the file.
+var errNotFound = errors.New("file not found in parent folders")
+
+// ResolveConfigPath returns the path of the configuration file a dependency
+// `config_path` points at, relative to dir.
+func ResolveConfigPath(dir, configPath string) string {
+	path := configPath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if filepath.Ext(path) == ".hcl" {
+		return path
+	}
+
+	return filepath.Join(path, UnitConfigFile)
+}
+
+// resolvePath evaluates a path expression of the file at filename, and
+// returns the absolute path it refers to.
+func resolvePath(filename string, expr hcl.Expression) (string, bool) {
+	path, ok := evalString(filename, expr)
+	if !ok {
+		return "", false
+	}
+
+	return absPath(filepath.Dir(filename), path), true
+}
+
+// resolveLocalSource evaluates a module source expression of the file at
+// filename, and returns the absolute path of the module when the source is
+// a local path.
+func resolveLocalSource(filename string, expr hcl.Expression) (string, bool) {
+	source, ok := evalString(filename, expr)
+	if !ok || !IsLocalSource(source) {
+		return "", false
+	}
+
+	return absPath(filepath.Dir(filename), source), true
+}
+
+// evalString evaluates an expression of the file at filename to a string.
+//
+// Only the functions that are commonly used to build paths are available, so
+// expressions that depend on anything else, such as locals, cannot be
+// evaluated.
+func evalString(filename string, expr hcl.Expression) (string, bool) {
+	value, diags := expr.Value(newEvalContext(filename))
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", false
+	}
+
+	return value.AsString(), true
+}
+
+// IsLocalSource reports whether a source is a path on the local filesystem,
+// rather than a remote or registry source.
+func IsLocalSource(source string) bool {
+	return strings.HasPrefix(source, "./") ||
+		strings.HasPrefix(source, "../") ||
+		filepath.IsAbs(source)
+}
+
+func absPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(dir, path)
+}
+
+// newEvalContext returns the context path expressions of the file at
+// filename are evaluated in.
+func newEvalContext(filename string) *hcl.EvalContext {
+	dir := filepath.Dir(filename)
+
+	return &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"find_in_parent_folders":      findInParentFoldersFunc(dir),
+			"get_terragrunt_dir":          constantFunc(dir),
+			"get_original_terragrunt_dir": constantFunc(dir),
+		},
+	}
+}
+
+// findInParentFoldersFunc mirrors Terragrunt's find_in_parent_folders,
+// searching the parent folders of dir for a file.
+func findInParentFoldersFunc(dir string) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{Name: "args", Type: cty.String},
+		Type:     function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			name := UnitConfigFile
+			if len(args) > 0 {
+				name = args[0].AsString()
+			}
+
+			for current := filepath.Dir(dir); ; current = filepath.Dir(current) {
+				path := filepath.Join(current, name)
+				if _, err := os.Stat(path); err == nil {
+					return cty.StringVal(path), nil
+				}
+
+				if current == filepath.Dir(current) {
+					break
+				}
+			}
+
+			if len(args) > 1 {
+				return args[1], nil
+			}
+
+			return cty.NilVal, errNotFound
+		},
+	})
+}
+
+// constantFunc returns a function without parameters that returns value.
+func constantFunc(value string) function.Function {
+	return function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.StringVal(value), nil
+		},
+	})
+}
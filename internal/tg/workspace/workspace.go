@@ -0,0 +1,494 @@
+// Package workspace indexes the Terragrunt files of a workspace, so that
+// features that cross files work without the editor opening every file.
+//
+// Files are only parsed into an AST, along with the paths they include and
+// depend on. Opened documents are still handled by the Terragrunt state.
+package workspace
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"terragrunt-ls/internal/ast"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/symbols"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"go.lsp.dev/protocol"
+)
+
+// skippedDirs are the directories that are never scanned, as they hold
+// copies of configurations generated by Terragrunt, or version control data.
+var skippedDirs = map[string]bool{
+	".terragrunt-cache": true,
+	".terragrunt-stack": true,
+	".git":              true,
+}
+
+// File is an indexed file.
+type File struct {
+	AST *ast.IndexedAST
+
+	// Path is the absolute path of the file.
+	Path string
+
+	// Includes are the files included by the file.
+	Includes []Include
+
+	// Dependencies are the configurations of the units the file depends
+	// on, through `dependency` and `dependencies` blocks.
+	Dependencies []Dependency
+
+	// Source is the absolute path of the module of a unit, when the source
+	// of its `terraform` block is a local path.
+	Source string
+
+	// Symbols are the symbols declared in the file.
+	Symbols []symbols.Symbol
+
+	// IncludeReferences are the `include.<label>.<attr>.<name>` references
+	// of the file.
+	IncludeReferences []ast.IncludeReference
+
+	// hash is the hash of the content the file was indexed from, and is
+	// empty for files that were not read from disk.
+	hash string
+
+	FileType store.FileType
+}
+
+// Include is a file included through an `include` block.
+type Include struct {
+	// Name is the label of the `include` block.
+	Name string
+
+	// Path is the absolute path of the included file.
+	Path string
+}
+
+// Dependency is a reference from a file to the configuration of a unit it
+// depends on.
+type Dependency struct {
+	// Path is the absolute path of the configuration of the unit.
+	Path string
+
+	// Name is the label of the `dependency` block, and is empty for the
+	// paths of a `dependencies` block.
+	Name string
+
+	// Range is the range of the expression of the path.
+	Range protocol.Range
+}
+
+// Index holds the indexed files of a workspace.
+//
+// It is safe for concurrent use. Files returned by the index must not be
+// modified, as they are shared with other callers.
+type Index struct {
+	// files maps absolute paths to indexed files.
+	files map[string]*File
+
+	// dependents maps the absolute paths of unit configurations to the
+	// paths of the indexed files that depend on them.
+	dependents map[string]map[string]bool
+
+	// cache, when set, is read and written by Scan.
+	cache *Cache
+
+	mu sync.RWMutex
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{
+		files:      map[string]*File{},
+		dependents: map[string]map[string]bool{},
+	}
+}
+
+// SetCache sets the cache Scan restores unchanged files from, and saves the
+// index to once it is done.
+func (i *Index) SetCache(cache *Cache) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.cache = cache
+}
+
+// Scan indexes the Terragrunt files under root, along with the files they
+// include, even when those are outside root.
+//
+// When the index has a cache, files whose content did not change since it
+// was saved are restored from it instead of being parsed, and the cache is
+// saved once every file is indexed. Failing to read or write the cache is
+// logged, and never fails the scan.
+//
+// progress, when set, is called after each file is indexed with the number
+// of files indexed so far and the number of files found so far. Scan stops
+// early and returns ctx.Err() when ctx is cancelled.
+func (i *Index) Scan(ctx context.Context, l logger.Logger, root string, progress func(done, total int)) error {
+	pending, err := findFiles(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	i.mu.RLock()
+	cache := i.cache
+	i.mu.RUnlock()
+
+	var cached map[string]cacheEntry
+
+	if cache != nil {
+		cached, err = cache.load()
+		if err != nil {
+			l.Warn("Ignoring unreadable index cache", "path", cache.Path(), "error", err)
+		}
+	}
+
+	restored := 0
+
+	seen := map[string]bool{}
+	for _, path := range pending {
+		seen[path] = true
+	}
+
+	for done := 0; done < len(pending); done++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		file, fromCache, err := i.scanFile(l, pending[done], cached)
+		if err != nil {
+			l.Debug("Failed to index file", "path", pending[done], "error", err)
+		}
+
+		if fromCache {
+			restored++
+		}
+
+		if file != nil {
+			for _, include := range file.Includes {
+				if !seen[include.Path] {
+					seen[include.Path] = true
+					pending = append(pending, include.Path)
+				}
+			}
+		}
+
+		if progress != nil {
+			progress(done+1, len(pending))
+		}
+	}
+
+	l.Debug("Indexed workspace", "root", root, "files", len(pending), "cached", restored)
+
+	if cache != nil {
+		if err := cache.save(i.Files()); err != nil {
+			l.Warn("Failed to save index cache", "path", cache.Path(), "error", err)
+		}
+	}
+
+	return nil
+}
+
+// scanFile indexes the file at path like IndexFile, restoring it from cached
+// when its content did not change, and reports whether it was restored.
+func (i *Index) scanFile(l logger.Logger, path string, cached map[string]cacheEntry) (*File, bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		i.Remove(path)
+
+		return nil, false, err
+	}
+
+	if entry, ok := cached[path]; ok && entry.valid(content) {
+		file := entry.file(path)
+		i.add(file)
+
+		return file, true, nil
+	}
+
+	file := parseFile(l, path, content)
+	i.add(file)
+
+	return file, false, nil
+}
+
+// IndexFile (re)indexes the file at path, which must be absolute.
+//
+// When the file cannot be read, it is removed from the index and the error
+// is returned. Files with syntax errors are indexed as far as they parse.
+func (i *Index) IndexFile(l logger.Logger, path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		i.Remove(path)
+
+		return nil, err
+	}
+
+	file := parseFile(l, path, content)
+	i.add(file)
+
+	return file, nil
+}
+
+// parseFile returns the indexed file for the content of the file at path.
+func parseFile(l logger.Logger, path string, content []byte) *File {
+	indexedAST, err := ast.ParseHCLFile(path, content)
+	if err != nil {
+		l.Debug("Indexed file has syntax errors", "path", path, "error", err)
+	}
+
+	file := NewFile(path, indexedAST)
+	file.hash = hashContent(content)
+
+	return file
+}
+
+// add adds file to the index, replacing the file at the same path.
+func (i *Index) add(file *File) {
+	path := file.Path
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(path)
+	i.files[path] = file
+
+	for _, dep := range file.Dependencies {
+		if i.dependents[dep.Path] == nil {
+			i.dependents[dep.Path] = map[string]bool{}
+		}
+
+		i.dependents[dep.Path][path] = true
+	}
+}
+
+// NewFile returns the indexed file for the AST of the file at path, without
+// adding it to an index.
+func NewFile(path string, indexedAST *ast.IndexedAST) *File {
+	file := &File{
+		Path:     path,
+		AST:      indexedAST,
+		FileType: store.DetectFileType(path),
+	}
+
+	if indexedAST == nil || indexedAST.HCLFile == nil {
+		return file
+	}
+
+	if body, ok := indexedAST.HCLFile.Body.(*hclsyntax.Body); ok {
+		file.Includes, file.Dependencies, file.Source = references(path, body)
+		file.IncludeReferences = ast.IncludeReferences(body)
+	}
+
+	file.Symbols = symbols.Declarations(indexedAST)
+
+	return file
+}
+
+// Remove removes the file at path from the index.
+func (i *Index) Remove(path string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(path)
+}
+
+// remove removes the file at path from the index. It must be called with mu
+// held.
+func (i *Index) remove(path string) {
+	file, ok := i.files[path]
+	if !ok {
+		return
+	}
+
+	for _, dep := range file.Dependencies {
+		delete(i.dependents[dep.Path], path)
+
+		if len(i.dependents[dep.Path]) == 0 {
+			delete(i.dependents, dep.Path)
+		}
+	}
+
+	delete(i.files, path)
+}
+
+// File returns the indexed file at path, if any.
+func (i *Index) File(path string) (*File, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	file, ok := i.files[path]
+
+	return file, ok
+}
+
+// Includers returns the indexed files that include the file at path, sorted
+// by path.
+func (i *Index) Includers(path string) []*File {
+	var includers []*File
+
+	for _, file := range i.Files() {
+		if slices.ContainsFunc(file.Includes, func(include Include) bool { return include.Path == path }) {
+			includers = append(includers, file)
+		}
+	}
+
+	return includers
+}
+
+// Dependents returns the indexed files that depend on the unit configuration
+// at path, sorted by path.
+func (i *Index) Dependents(path string) []*File {
+	i.mu.RLock()
+	dependents := make([]*File, 0, len(i.dependents[path]))
+
+	for dependent := range i.dependents[path] {
+		dependents = append(dependents, i.files[dependent])
+	}
+	i.mu.RUnlock()
+
+	sortFiles(dependents)
+
+	return dependents
+}
+
+// Files returns the indexed files, sorted by path.
+func (i *Index) Files() []*File {
+	i.mu.RLock()
+	files := make([]*File, 0, len(i.files))
+
+	for _, file := range i.files {
+		files = append(files, file)
+	}
+	i.mu.RUnlock()
+
+	sortFiles(files)
+
+	return files
+}
+
+func sortFiles(files []*File) {
+	slices.SortFunc(files, func(a, b *File) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+}
+
+// IsConfigFile reports whether path is the name of a Terragrunt
+// configuration file that is indexed when scanning a workspace.
+func IsConfigFile(path string) bool {
+	return store.DetectFileType(path) != store.FileTypeUnknown
+}
+
+// findFiles returns the absolute paths of the Terragrunt configuration files
+// under root, in lexical order.
+func findFiles(ctx context.Context, root string) ([]string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than failing the scan.
+			if entry != nil && entry.IsDir() && path != root {
+				return fs.SkipDir
+			}
+
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != root && skippedDirs[entry.Name()] {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.Type().IsRegular() && IsConfigFile(path) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	return paths, err
+}
+
+// references returns the absolute paths of the files included by the file
+// at filename, of the configurations of its dependencies, and of its local
+// module.
+func references(filename string, body *hclsyntax.Body) (includes []Include, dependencies []Dependency, source string) {
+	dir := filepath.Dir(filename)
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "include":
+			attr, ok := block.Body.Attributes["path"]
+			if !ok || len(block.Labels) == 0 {
+				continue
+			}
+
+			if path, ok := resolvePath(filename, attr.Expr); ok {
+				includes = append(includes, Include{Name: block.Labels[0], Path: path})
+			}
+
+		case "dependency":
+			attr, ok := block.Body.Attributes["config_path"]
+			if !ok || len(block.Labels) == 0 {
+				continue
+			}
+
+			if path, ok := resolvePath(filename, attr.Expr); ok {
+				dependencies = append(dependencies, Dependency{
+					Path:  ResolveConfigPath(dir, path),
+					Name:  block.Labels[0],
+					Range: ast.FromHCLRange(attr.Expr.Range()),
+				})
+			}
+
+		case "dependencies":
+			attr, ok := block.Body.Attributes["paths"]
+			if !ok {
+				continue
+			}
+
+			tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+			if !ok {
+				continue
+			}
+
+			for _, item := range tuple.Exprs {
+				if path, ok := resolvePath(filename, item); ok {
+					dependencies = append(dependencies, Dependency{
+						Path:  ResolveConfigPath(dir, path),
+						Range: ast.FromHCLRange(item.Range()),
+					})
+				}
+			}
+
+		case "terraform":
+			if attr, ok := block.Body.Attributes["source"]; ok {
+				if path, ok := resolveLocalSource(filename, attr.Expr); ok {
+					source = path
+				}
+			}
+		}
+	}
+
+	return includes, dependencies, source
+}
@@ -0,0 +1,276 @@
+package workspace_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/protocol"
+
+	"terragrunt-ls/internal/testutils"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/workspace"
+)
+
+// createTree creates files under dir, keyed by their slash-separated path
+// relative to dir.
+func createTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+
+		_, err := testutils.CreateFile(filepath.Dir(path), filepath.Base(path), content)
+		require.NoError(t, err)
+	}
+}
+
+func paths(files []*workspace.File) []string {
+	result := make([]string, 0, len(files))
+
+	for _, file := range files {
+		result = append(result, file.Path)
+	}
+
+	return result
+}
+
+func TestIndex_Scan(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "live")
+
+	createTree(t, tmpDir, map[string]string{
+		"root.hcl":           "locals {\n  region = \"us-east-1\"\n}\n",
+		"live/common.hcl":    "inputs = {}\n",
+		"live/unrelated.hcl": "inputs = {}\n",
+		"live/app/terragrunt.hcl": `include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+include "common" {
+  path = "${get_terragrunt_dir()}/../common.hcl"
+}
+
+include "unresolved" {
+  path = local.path
+}
+
+dependency "db" {
+  config_path = "../db"
+}
+
+dependencies {
+  paths = ["../vpc", "../shared/terragrunt.hcl"]
+}
+
+terraform {
+  source = "../../modules//app"
+}
+`,
+		"live/db/terragrunt.hcl":                          "",
+		"live/db/.terragrunt-cache/abc/terragrunt.hcl":    "",
+		"live/stack/terragrunt.stack.hcl":                 "unit \"app\" {\n  source = \"../app\"\n  path   = \"app\"\n}\n",
+		"live/stack/.terragrunt-stack/app/terragrunt.hcl": "",
+		"live/stack/terragrunt.values.hcl":                "region = \"us-east-1\"\n",
+		"live/broken/terragrunt.hcl":                      "locals {\n",
+	})
+
+	index := workspace.NewIndex()
+
+	var calls [][2]int
+
+	err := index.Scan(t.Context(), testutils.NewTestLogger(t), root, func(done, total int) {
+		calls = append(calls, [2]int{done, total})
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "app", "terragrunt.hcl"),
+		filepath.Join(root, "broken", "terragrunt.hcl"),
+		filepath.Join(root, "common.hcl"),
+		filepath.Join(root, "db", "terragrunt.hcl"),
+		filepath.Join(root, "stack", "terragrunt.stack.hcl"),
+		filepath.Join(root, "stack", "terragrunt.values.hcl"),
+		filepath.Join(tmpDir, "root.hcl"),
+	}, paths(index.Files()))
+
+	app, ok := index.File(filepath.Join(root, "app", "terragrunt.hcl"))
+	require.True(t, ok)
+	assert.Equal(t, store.FileTypeUnit, app.FileType)
+	assert.NotNil(t, app.AST)
+	assert.Equal(t, []workspace.Include{
+		{Name: "root", Path: filepath.Join(tmpDir, "root.hcl")},
+		{Name: "common", Path: filepath.Join(root, "common.hcl")},
+	}, app.Includes)
+	assert.Equal(t, []workspace.Dependency{
+		{
+			Path: filepath.Join(root, "db", "terragrunt.hcl"),
+			Name: "db",
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 13, Character: 16},
+				End:   protocol.Position{Line: 13, Character: 23},
+			},
+		},
+		{
+			Path: filepath.Join(root, "vpc", "terragrunt.hcl"),
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 17, Character: 11},
+				End:   protocol.Position{Line: 17, Character: 19},
+			},
+		},
+		{
+			Path: filepath.Join(root, "shared", "terragrunt.hcl"),
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 17, Character: 21},
+				End:   protocol.Position{Line: 17, Character: 47},
+			},
+		},
+	}, app.Dependencies)
+	assert.Equal(t, filepath.Join(tmpDir, "modules", "app"), app.Source)
+
+	assert.Equal(t, []*workspace.File{app}, index.Includers(filepath.Join(tmpDir, "root.hcl")))
+	assert.Empty(t, index.Includers(filepath.Join(root, "unrelated.hcl")))
+
+	stack, ok := index.File(filepath.Join(root, "stack", "terragrunt.stack.hcl"))
+	require.True(t, ok)
+	assert.Equal(t, store.FileTypeStack, stack.FileType)
+
+	// Files with syntax errors are still indexed.
+	broken, ok := index.File(filepath.Join(root, "broken", "terragrunt.hcl"))
+	require.True(t, ok)
+	assert.NotNil(t, broken.AST)
+
+	// The includes of the first file are found as soon as it is indexed, so
+	// the total grows from the 5 files found by the walk.
+	require.Len(t, calls, 7)
+	assert.Equal(t, [2]int{1, 7}, calls[0])
+	assert.Equal(t, [2]int{7, 7}, calls[6])
+}
+
+func TestIndex_ScanCancelled(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	createTree(t, tmpDir, map[string]string{"app/terragrunt.hcl": ""})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	index := workspace.NewIndex()
+
+	err := index.Scan(ctx, testutils.NewTestLogger(t), tmpDir, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, index.Files())
+}
+
+func TestIndex_IndexFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	createTree(t, tmpDir, map[string]string{"app/terragrunt.hcl": ""})
+
+	l := testutils.NewTestLogger(t)
+	path := filepath.Join(tmpDir, "app", "terragrunt.hcl")
+
+	index := workspace.NewIndex()
+
+	_, err := index.IndexFile(l, path)
+	require.NoError(t, err)
+
+	createTree(t, tmpDir, map[string]string{"app/terragrunt.hcl": "dependency \"db\" {\n  config_path = \"/db\"\n}\n"})
+
+	file, err := index.IndexFile(l, path)
+	require.NoError(t, err)
+	require.Len(t, file.Dependencies, 1)
+	assert.Equal(t, filepath.Join("/db", "terragrunt.hcl"), file.Dependencies[0].Path)
+
+	indexed, ok := index.File(path)
+	require.True(t, ok)
+	assert.Same(t, file, indexed)
+
+	// Files that are gone are dropped from the index.
+	require.NoError(t, os.Remove(path))
+
+	_, err = index.IndexFile(l, path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, ok = index.File(path)
+	assert.False(t, ok)
+}
+
+func TestIndex_Dependents(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	createTree(t, tmpDir, map[string]string{
+		"vpc/terragrunt.hcl": "",
+		"db/terragrunt.hcl":  "dependency \"vpc\" {\n  config_path = \"../vpc\"\n}\n",
+		"app/terragrunt.hcl": "dependencies {\n  paths = [\"../vpc\", \"../db\"]\n}\n",
+	})
+
+	l := testutils.NewTestLogger(t)
+	vpc := filepath.Join(tmpDir, "vpc", "terragrunt.hcl")
+	db := filepath.Join(tmpDir, "db", "terragrunt.hcl")
+	app := filepath.Join(tmpDir, "app", "terragrunt.hcl")
+
+	index := workspace.NewIndex()
+	require.NoError(t, index.Scan(t.Context(), l, tmpDir, nil))
+
+	assert.Equal(t, []string{app, db}, paths(index.Dependents(vpc)))
+	assert.Equal(t, []string{app}, paths(index.Dependents(db)))
+	assert.Empty(t, index.Dependents(app))
+
+	// Re-indexing a file drops the dependencies it no longer has.
+	createTree(t, tmpDir, map[string]string{"db/terragrunt.hcl": ""})
+
+	_, err := index.IndexFile(l, db)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{app}, paths(index.Dependents(vpc)))
+
+	index.Remove(app)
+
+	assert.Empty(t, index.Dependents(vpc))
+	assert.Empty(t, index.Dependents(db))
+}
+
+func TestResolveConfigPath(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name       string
+		configPath string
+		expected   string
+	}{
+		{
+			name:       "relative directory",
+			configPath: "../vpc",
+			expected:   "/live/vpc/terragrunt.hcl",
+		},
+		{
+			name:       "absolute directory",
+			configPath: "/other/vpc",
+			expected:   "/other/vpc/terragrunt.hcl",
+		},
+		{
+			name:       "configuration file",
+			configPath: "../vpc/custom.hcl",
+			expected:   "/live/vpc/custom.hcl",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, filepath.FromSlash(tt.expected), workspace.ResolveConfigPath(filepath.FromSlash("/live/app"), tt.configPath))
+		})
+	}
+}
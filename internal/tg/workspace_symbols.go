@@ -0,0 +1,61 @@
+package tg
+
+import (
+	"maps"
+	"slices"
+	"terragrunt-ls/internal/logger"
+	"terragrunt-ls/internal/lsp"
+	"terragrunt-ls/internal/tg/store"
+	"terragrunt-ls/internal/tg/symbols"
+	"terragrunt-ls/internal/tg/workspace"
+
+	"go.lsp.dev/protocol"
+)
+
+// WorkspaceSymbols returns the symbols of the indexed files of every
+// workspace folder that fuzzily match query. Documents that are open in the
+// editor are searched in their last parsed version instead.
+func (s *State) WorkspaceSymbols(l logger.Logger, id lsp.ID, query string) lsp.WorkspaceSymbolResponse {
+	files := map[string]*workspace.File{}
+
+	for _, index := range s.Indexes() {
+		for _, file := range index.Files() {
+			if _, ok := files[file.Path]; !ok {
+				files[file.Path] = file
+			}
+		}
+	}
+
+	s.mu.RLock()
+	for path, st := range s.Configs {
+		if st.Owner != store.OwnerEditor {
+			continue
+		}
+
+		files[path] = workspace.NewFile(path, st.AST)
+	}
+	s.mu.RUnlock()
+
+	var all []protocol.SymbolInformation
+
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		root, _ := s.folder(path)
+		file := files[path]
+		all = append(all, symbols.File(root, file.Path, file.FileType, file.Symbols)...)
+	}
+
+	result := symbols.Search(query, all)
+
+	l.Debug(
+		"Searched workspace symbols",
+		"query", query,
+		"files", len(files),
+		"symbols", len(all),
+		"matches", len(result),
+	)
+
+	return lsp.WorkspaceSymbolResponse{
+		Response: lsp.Response{RPC: lsp.RPCVersion, ID: id},
+		Result:   result,
+	}
+}
@@ -1,21 +1,27 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
-	"io"
 	"os"
+	"os/signal"
+	"syscall"
 	"terragrunt-ls/internal/config"
 	"terragrunt-ls/internal/logger"
-	"terragrunt-ls/internal/lsp"
-	"terragrunt-ls/internal/rpc"
-	"terragrunt-ls/internal/tg"
-
-	"go.lsp.dev/protocol"
+	"terragrunt-ls/internal/server"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run serves LSP sessions and returns the exit code.
+//
+// By default, a single session is served over stdio. When a listen address
+// is configured, one session is served per connection until the process is
+// interrupted.
+//
+// It is separate from main so that deferred cleanup runs before os.Exit.
+func run() int {
 	cfg := config.Load()
 
 	l := logger.NewLogger(cfg.LogFile, cfg.LogLevel)
@@ -27,279 +33,29 @@ func main() {
 
 	l.Info("Initializing terragrunt-ls")
 
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Split(rpc.Split)
-
-	ctx := context.Background()
-	state := tg.NewState()
-	writer := os.Stdout
-
-	for scanner.Scan() {
-		msg := scanner.Bytes()
-
-		method, contents, err := rpc.DecodeMessage(msg)
-		if err != nil {
-			l.Error("Got an error decoding message from client", "err", err)
-
-			continue
-		}
+	if cfg.Listen == "" {
+		s := server.New(l, os.Stdout, server.WithCacheDir(cfg.CacheDir))
 
-		handleMessage(ctx, l, writer, state, method, contents)
+		return s.Serve(context.Background(), os.Stdin)
 	}
-}
-
-func handleMessage(ctx context.Context, l logger.Logger, writer io.Writer, state tg.State, method string, contents []byte) {
-	l.Debug("Received msg", "method", method, "contents", string(contents))
-
-	switch method {
-	case protocol.MethodInitialize:
-		var request lsp.InitializeRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error("Failed to parse initialize request", "err", err)
-		}
-
-		l.Debug("Connected",
-			"Name", request.Params.ClientInfo.Name,
-			"Version", request.Params.ClientInfo.Version)
-
-		msg := lsp.NewInitializeResponse(request.ID)
-		writeResponse(l, writer, msg)
-
-		l.Debug("Initialized")
-
-	case protocol.MethodTextDocumentDidOpen:
-		var notification lsp.DidOpenTextDocumentNotification
-		if err := json.Unmarshal(contents, &notification); err != nil {
-			l.Error(
-				"Failed to parse didOpen request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"Opened",
-			"URI", notification.Params.TextDocument.URI,
-			"LanguageID", notification.Params.TextDocument.LanguageID,
-			"Version", notification.Params.TextDocument.Version,
-			"Text", notification.Params.TextDocument.Text,
-		)
-
-		diagnostics := state.OpenDocument(ctx, l, notification.Params.TextDocument.URI, notification.Params.TextDocument.Text)
-		writeResponse(l, writer, lsp.PublishDiagnosticsNotification{
-			Notification: lsp.Notification{
-				RPC:    lsp.RPCVersion,
-				Method: protocol.MethodTextDocumentPublishDiagnostics,
-			},
-			Params: protocol.PublishDiagnosticsParams{
-				URI:         notification.Params.TextDocument.URI,
-				Diagnostics: diagnostics,
-			},
-		})
-
-		l.Debug(
-			"Document opened",
-			"URI", notification.Params.TextDocument.URI,
-		)
-
-	case protocol.MethodTextDocumentDidChange:
-		var notification lsp.DidChangeTextDocumentNotification
-		if err := json.Unmarshal(contents, &notification); err != nil {
-			l.Error(
-				"Failed to parse didChange request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"Changed",
-			"URI", notification.Params.TextDocument.URI,
-			"Changes", notification.Params.ContentChanges,
-		)
-
-		for _, change := range notification.Params.ContentChanges {
-			l.Debug(
-				"Change",
-				"Range", change.Range,
-				"Text", change.Text,
-			)
 
-			diagnostics := state.UpdateDocument(ctx, l, notification.Params.TextDocument.URI, change.Text)
-			writeResponse(l, writer, lsp.PublishDiagnosticsNotification{
-				Notification: lsp.Notification{
-					RPC:    lsp.RPCVersion,
-					Method: protocol.MethodTextDocumentPublishDiagnostics,
-				},
-				Params: protocol.PublishDiagnosticsParams{
-					URI:         notification.Params.TextDocument.URI,
-					Diagnostics: diagnostics,
-				},
-			})
-		}
-
-		l.Debug(
-			"Document changed",
-			"URI", notification.Params.TextDocument.URI,
-		)
-
-	case protocol.MethodTextDocumentHover:
-		var request lsp.HoverRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Debug(
-				"Failed to parse hover request",
-				"error",
-				err,
-			)
-		}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-		l.Debug(
-			"Hover",
-			"URI", request.Params.TextDocument.URI,
-			"Position", request.Params.Position,
-		)
-
-		response := state.Hover(l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
-
-		writeResponse(l, writer, response)
-
-	case protocol.MethodTextDocumentDefinition:
-		var request lsp.DefinitionRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error(
-				"Failed to parse definition request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"Definition",
-			"URI", request.Params.TextDocument.URI,
-			"Position", request.Params.Position,
-		)
-
-		response := state.Definition(l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
-
-		writeResponse(l, writer, response)
-
-	case protocol.MethodTextDocumentCompletion:
-		var request lsp.CompletionRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error(
-				"Failed to parse completion request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"Completion",
-			"URI", request.Params.TextDocument.URI,
-			"Position", request.Params.Position,
-		)
-
-		response := state.TextDocumentCompletion(l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
-
-		l.Debug(
-			"Completion response",
-			"Response", response,
-		)
-
-		writeResponse(l, writer, response)
-
-	case protocol.MethodTextDocumentFormatting:
-		var request lsp.FormatRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error(
-				"Failed to parse format request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"Formatting",
-			"URI", request.Params.TextDocument.URI,
-		)
-
-		response := state.TextDocumentFormatting(l, request.ID, request.Params.TextDocument.URI)
-
-		writeResponse(l, writer, response)
-
-	case protocol.MethodTextDocumentReferences:
-		var request lsp.ReferencesRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error(
-				"Failed to parse references request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"References",
-			"URI", request.Params.TextDocument.URI,
-			"Position", request.Params.Position,
-			"IncludeDeclaration", request.Params.Context.IncludeDeclaration,
-		)
-
-		response := state.TextDocumentReferences(l, request.ID, request.Params.TextDocument.URI, request.Params.Position, request.Params.Context.IncludeDeclaration)
-
-		writeResponse(l, writer, response)
-
-	case protocol.MethodTextDocumentPrepareRename:
-		var request lsp.PrepareRenameRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error(
-				"Failed to parse prepare rename request",
-				"error",
-				err,
-			)
-		}
-
-		l.Debug(
-			"Prepare rename",
-			"URI", request.Params.TextDocument.URI,
-			"Position", request.Params.Position,
-		)
-
-		response := state.PrepareRename(l, request.ID, request.Params.TextDocument.URI, request.Params.Position)
-
-		writeResponse(l, writer, response)
-
-	case protocol.MethodTextDocumentRename:
-		var request lsp.RenameRequest
-		if err := json.Unmarshal(contents, &request); err != nil {
-			l.Error(
-				"Failed to parse rename request",
-				"error",
-				err,
-			)
-		}
+	ln, err := server.Listen(cfg.Listen)
+	if err != nil {
+		l.Error("Failed to start listener", "error", err)
 
-		l.Debug(
-			"Rename",
-			"URI", request.Params.TextDocument.URI,
-			"Position", request.Params.Position,
-			"NewName", request.Params.NewName,
-		)
+		return server.ExitCodeFailure
+	}
 
-		response := state.TextDocumentRename(l, request.ID, request.Params.TextDocument.URI, request.Params.Position, request.Params.NewName)
+	if err := server.ServeListener(ctx, l, ln, server.WithCacheDir(cfg.CacheDir)); err != nil {
+		l.Error("Stopped accepting connections", "error", err)
 
-		writeResponse(l, writer, response)
+		return server.ExitCodeFailure
 	}
-}
 
-func writeResponse(l logger.Logger, writer io.Writer, msg any) {
-	reply := rpc.EncodeMessage(msg)
+	l.Info("Shut down listener")
 
-	_, err := writer.Write([]byte(reply))
-	if err != nil {
-		l.Error(
-			"Failed to write response",
-			"error",
-			err,
-		)
-	}
+	return server.ExitCodeSuccess
 }